@@ -0,0 +1,114 @@
+package roflmeta
+
+import (
+	"sort"
+	"strconv"
+)
+
+type seasonEpisodes struct {
+	title      string
+	titleOrder int
+	season     string
+	part       int
+	number     int
+	isNumeric  bool
+	order      int
+	minEpisode int
+	maxEpisode int
+	entries    []*fileEntry
+}
+
+type seasonPart struct {
+	title  string
+	season string
+	part   int
+}
+
+// calcAbsoluteEpisodes orders seasons and counts episodes across them, each title is counted separately
+// Seasons without a number are ordered by their first appearance, after the unnamed season
+// Only regular episodes with integer numbers are taken into account, season 0 is skipped as it usually contains specials
+// Seasons and parts of a split season either continue numbering of the previous one or restart it, both are supported
+func calcAbsoluteEpisodes(entries []*fileEntry) {
+	seasonMap := make(map[seasonPart]*seasonEpisodes)
+	titleOrder := make(map[string]int)
+	for _, entry := range entries {
+		if !entry.isVideo || entry.kind != KindRegular {
+			continue
+		}
+		episode, err := strconv.Atoi(entry.result.Episode)
+		if err != nil || episode < 0 {
+			continue
+		}
+		key := seasonPart{title: entry.result.Title, season: entry.result.Season, part: entry.result.Part}
+		season, ok := seasonMap[key]
+		if !ok {
+			if _, ok := titleOrder[entry.result.Title]; !ok {
+				titleOrder[entry.result.Title] = len(titleOrder)
+			}
+			number, err := strconv.Atoi(entry.result.Season)
+			season = &seasonEpisodes{
+				title:      entry.result.Title,
+				titleOrder: titleOrder[entry.result.Title],
+				season:     entry.result.Season,
+				part:       entry.result.Part,
+				number:     number,
+				isNumeric:  err == nil,
+				order:      len(seasonMap),
+				minEpisode: episode,
+			}
+			seasonMap[key] = season
+		}
+		if season.isNumeric && season.number == 0 {
+			continue
+		}
+		if episode < season.minEpisode {
+			season.minEpisode = episode
+		}
+		season.maxEpisode = max(season.maxEpisode, episode)
+		// the last file may hold several episodes, e.g. "12-13"
+		if episodeEnd, err := strconv.Atoi(entry.result.EpisodeEnd); err == nil {
+			season.maxEpisode = max(season.maxEpisode, episodeEnd)
+		}
+		season.entries = append(season.entries, entry)
+	}
+
+	seasons := make([]*seasonEpisodes, 0, len(seasonMap))
+	for _, season := range seasonMap {
+		seasons = append(seasons, season)
+	}
+	sort.Slice(seasons, func(i, j int) bool {
+		a, b := seasons[i], seasons[j]
+		if a.titleOrder != b.titleOrder {
+			return a.titleOrder < b.titleOrder
+		}
+		if (a.season == "") != (b.season == "") {
+			return a.season == ""
+		}
+		if a.isNumeric != b.isNumeric {
+			return a.isNumeric
+		}
+		if a.isNumeric && a.number != b.number {
+			return a.number < b.number
+		}
+		if a.season == b.season {
+			return a.part < b.part
+		}
+		return a.order < b.order
+	})
+
+	offset := 0
+	for i, season := range seasons {
+		if i > 0 && seasons[i-1].title != season.title {
+			offset = 0
+		}
+		// next season or part continues numbering, e.g. season 2 starts with episode 13
+		if i > 0 && seasons[i-1].title == season.title && season.minEpisode > seasons[i-1].maxEpisode {
+			offset -= seasons[i-1].maxEpisode
+		}
+		for _, entry := range season.entries {
+			episode, _ := strconv.Atoi(entry.result.Episode)
+			entry.result.AbsoluteEpisode = offset + episode
+		}
+		offset += season.maxEpisode
+	}
+}
@@ -0,0 +1,110 @@
+package roflmeta
+
+import (
+	"testing"
+)
+
+func assertAbsolute(t *testing.T, actual []EpisodeMetadata, expected []int) {
+	if len(expected) != len(actual) {
+		t.Fatalf("Invalid result length, expected %d, got %d", len(expected), len(actual))
+	}
+	for i := range expected {
+		if expected[i] != actual[i].AbsoluteEpisode {
+			t.Fatalf("Invalid absolute episode at #%d, expected %d, got %d", i, expected[i], actual[i].AbsoluteEpisode)
+		}
+	}
+}
+
+func genAbsolute(from int, to int) []int {
+	result := make([]int, 0, to-from+1)
+	for i := from; i <= to; i++ {
+		result = append(result, i)
+	}
+	return result
+}
+
+func TestAbsoluteEpisodeSingleSeason(t *testing.T) {
+	input := genInput("[Judas] Hunter x Hunter (2011) - Episodes 001-148/[Judas] Hunter x Hunter (2011) - S01E%03d.mkv", 1, 148)
+	assertAbsolute(t, ParseMultipleEpisodeMetadata(input), genAbsolute(1, 148))
+}
+
+func TestAbsoluteEpisodeMultipleSeasons(t *testing.T) {
+	input := make([]string, 0, 256)
+	input = append(input, genInput("season 02/episode %02d.mkv", 1, 12)...)
+	input = append(input, genInput("season 01/episode %02d.mkv", 1, 24)...)
+
+	expected := make([]int, 0, 256)
+	expected = append(expected, genAbsolute(25, 36)...)
+	expected = append(expected, genAbsolute(1, 24)...)
+
+	assertAbsolute(t, ParseMultipleEpisodeMetadata(input), expected)
+}
+
+func TestAbsoluteEpisodeNamedSeasons(t *testing.T) {
+	input := make([]string, 0, 256)
+	input = append(input, genInput("Dr Stone Season 2/Dr Stone - %02d.mkv", 1, 11)...)
+	input = append(input, genInput("Dr Stone/Dr Stone - %02d.mkv", 1, 24)...)
+
+	expected := make([]int, 0, 256)
+	expected = append(expected, genAbsolute(25, 35)...)
+	expected = append(expected, genAbsolute(1, 24)...)
+
+	assertAbsolute(t, ParseMultipleEpisodeMetadata(input), expected)
+}
+
+func TestAbsoluteEpisodeSkipsSpecials(t *testing.T) {
+	input := make([]string, 0, 256)
+	input = append(input, genInput("season 00/episode %02d.mkv", 1, 2)...)
+	input = append(input, genInput("season 01/episode %02d.mkv", 1, 12)...)
+	input = append(input, "season 01/NCOP1.mkv")
+	input = append(input, genInput("season 02/episode %02d.mkv", 1, 12)...)
+
+	expected := make([]int, 0, 256)
+	expected = append(expected, 0, 0)
+	expected = append(expected, genAbsolute(1, 12)...)
+	expected = append(expected, 0)
+	expected = append(expected, genAbsolute(13, 24)...)
+
+	assertAbsolute(t, ParseMultipleEpisodeMetadata(input), expected)
+}
+
+func TestAbsoluteEpisodeParts(t *testing.T) {
+	input := make([]string, 0, 256)
+	input = append(input, genInput("Show Final Season Part 2 - %02d.mkv", 1, 12)...)
+	input = append(input, genInput("Show Final Season Part 1 - %02d.mkv", 1, 16)...)
+
+	expected := make([]int, 0, 256)
+	expected = append(expected, genAbsolute(17, 28)...)
+	expected = append(expected, genAbsolute(1, 16)...)
+
+	assertAbsolute(t, ParseMultipleEpisodeMetadata(input), expected)
+}
+
+func TestAbsoluteEpisodeContinuedPart(t *testing.T) {
+	input := make([]string, 0, 256)
+	input = append(input, genInput("Show Season 2 Part 1 - %02d.mkv", 1, 12)...)
+	input = append(input, genInput("Show Season 2 Part 2 - %02d.mkv", 13, 24)...)
+
+	assertAbsolute(t, ParseMultipleEpisodeMetadata(input), genAbsolute(1, 24))
+}
+
+func TestAbsoluteEpisodeContinuedSeason(t *testing.T) {
+	input := make([]string, 0, 256)
+	input = append(input, genInput("Show S01E%02d.mkv", 1, 12)...)
+	input = append(input, genInput("Show S02E%02d.mkv", 13, 24)...)
+
+	assertAbsolute(t, ParseMultipleEpisodeMetadata(input), genAbsolute(1, 24))
+}
+
+func TestAbsoluteEpisodeRangeEnd(t *testing.T) {
+	input := make([]string, 0, 256)
+	input = append(input, genInput("season 01/episode %02d.mkv", 1, 11)...)
+	input = append(input, "season 01/episode 12-13.mkv")
+	input = append(input, genInput("season 02/episode %02d.mkv", 1, 12)...)
+
+	expected := make([]int, 0, 256)
+	expected = append(expected, genAbsolute(1, 12)...)
+	expected = append(expected, genAbsolute(14, 25)...)
+
+	assertAbsolute(t, ParseMultipleEpisodeMetadata(input), expected)
+}
@@ -0,0 +1,78 @@
+package roflmeta
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const airDateLayout = "2006-01-02"
+
+// separators must be the same, it is checked in code, because backreferences are not supported
+var ymdRegex = regexp.MustCompile("(?:^|\\D)((?:19|20)\\d{2})([.\\-_ ])(\\d{1,2})([.\\-_ ])(\\d{1,2})(?:\\D|$)")
+var dmyRegex = regexp.MustCompile("(?:^|\\D)(\\d{1,2})([.\\-_ ])(\\d{1,2})([.\\-_ ])((?:19|20)\\d{2})(?:\\D|$)")
+var compactDateRegex = regexp.MustCompile("(?:^|\\D)((?:19|20)\\d{2})(\\d{2})(\\d{2})(?:\\D|$)")
+
+// newAirDate validates date, e.g. 2023-02-30 is rejected
+func newAirDate(year string, month string, day string) (time.Time, bool) {
+	y, _ := strconv.Atoi(year)
+	m, _ := strconv.Atoi(month)
+	d, _ := strconv.Atoi(day)
+	if m < 1 || m > 12 || d < 1 {
+		return time.Time{}, false
+	}
+	date := time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC)
+	if date.Month() != time.Month(m) || date.Day() != d {
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+// parseAirDate searches for a date like 2023.05.14, 2023-05-14, 14.05.2023, 05-14-2023 or 20230514
+// Day goes first if both day and month are 12 or less
+// Returns the date and its text in the string
+func parseAirDate(s string) (time.Time, string, bool) {
+	// checksums and other tags are often purely numerical
+	s = bracketRemoveRegex.ReplaceAllLiteralString(s, " ")
+	if test := ymdRegex.FindStringSubmatch(s); test != nil && test[2] == test[4] {
+		if date, ok := newAirDate(test[1], test[3], test[5]); ok {
+			return date, strings.Join(test[1:6], ""), true
+		}
+	}
+	if test := dmyRegex.FindStringSubmatch(s); test != nil && test[2] == test[4] {
+		if date, ok := newAirDate(test[5], test[3], test[1]); ok {
+			return date, strings.Join(test[1:6], ""), true
+		}
+		if date, ok := newAirDate(test[5], test[1], test[3]); ok {
+			return date, strings.Join(test[1:6], ""), true
+		}
+	}
+	if test := compactDateRegex.FindStringSubmatch(s); test != nil {
+		if date, ok := newAirDate(test[1], test[2], test[3]); ok {
+			return date, strings.Join(test[1:4], ""), true
+		}
+	}
+	return time.Time{}, "", false
+}
+
+// splitAirDateBatch checks whether dates are what changes between files, e.g. a daily show
+// Most files must have a date and most dates must be distinct, as a show may air several parts on the same day
+// Returns files without a date, e.g. "Show.Best.Of.mkv"
+func splitAirDateBatch(entries []*fileEntry) ([]*fileEntry, bool) {
+	dates := make(map[time.Time]struct{}, len(entries))
+	undated := make([]*fileEntry, 0)
+	for _, entry := range entries {
+		date, _, ok := parseAirDate(entry.base)
+		if !ok {
+			undated = append(undated, entry)
+			continue
+		}
+		dates[date] = struct{}{}
+	}
+	dated := len(entries) - len(undated)
+	if dated*2 <= len(entries) || len(dates) < 2 || len(dates)*2 <= dated {
+		return nil, false
+	}
+	return undated, true
+}
@@ -0,0 +1,43 @@
+package roflmeta
+
+import (
+	"github.com/go-playground/assert/v2"
+	"testing"
+	"time"
+)
+
+func assertAirDate(t *testing.T, s string, expected string) {
+	date, _, ok := parseAirDate(s)
+	if expected == "" {
+		assert.Equal(t, ok, false)
+		return
+	}
+	assert.Equal(t, ok, true)
+	assert.Equal(t, date.Format(airDateLayout), expected)
+}
+
+func TestAirDateOrders(t *testing.T) {
+	assertAirDate(t, "Show.2023.05.14.Guest.Name", "2023-05-14")
+	assertAirDate(t, "Show - 2023-05-14", "2023-05-14")
+	assertAirDate(t, "Show_2023_5_4", "2023-05-04")
+	assertAirDate(t, "Show 14.05.2023", "2023-05-14")
+	assertAirDate(t, "Show 05-14-2023", "2023-05-14")
+	assertAirDate(t, "Show 04.05.2023", "2023-05-04")
+	assertAirDate(t, "Show 20230514 Guest", "2023-05-14")
+}
+
+func TestAirDateInvalid(t *testing.T) {
+	assertAirDate(t, "Show 2023.02.30", "")
+	assertAirDate(t, "Show 2023.05-14", "")
+	assertAirDate(t, "Show 2023.13.01", "")
+	assertAirDate(t, "[Judas] Hunter x Hunter (2011) - S01E012", "")
+	assertAirDate(t, "[SubsPlease] Show - 01 (1080p) [20230514]", "")
+	assertAirDate(t, "[CBM]_Hellsing_Ultimate_-_06_-_[1080p-AC3]_[1CB8EDB0]", "")
+}
+
+func TestAirDateValue(t *testing.T) {
+	date, match, ok := parseAirDate("Show.2023.05.14.Guest.Name")
+	assert.Equal(t, ok, true)
+	assert.Equal(t, match, "2023.05.14")
+	assert.Equal(t, date, time.Date(2023, time.May, 14, 0, 0, 0, 0, time.UTC))
+}
@@ -0,0 +1,43 @@
+package roflmeta
+
+import (
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// VerifyChecksum computes CRC32 of the reader contents and compares it with expected checksum tag, e.g. "28B342E5"
+// Returned error is ErrNoChecksum if expected checksum is malformed
+func VerifyChecksum(r io.Reader, expected string) (bool, error) {
+	if !checksumLikeRegex.MatchString(expected) {
+		return false, ErrNoChecksum
+	}
+	expectedValue, err := strconv.ParseUint(expected, 16, 32)
+	if err != nil {
+		return false, ErrNoChecksum
+	}
+	hash := crc32.NewIEEE()
+	if _, err := io.Copy(hash, r); err != nil {
+		return false, err
+	}
+	return hash.Sum32() == uint32(expectedValue), nil
+}
+
+// VerifyFileChecksum computes CRC32 of the file and compares it with the checksum tag found in its name
+// Returned error is ErrNoChecksum if filename doesn't contain the tag
+func VerifyFileChecksum(path string) (bool, error) {
+	base := filepath.Base(path)
+	expected := parseChecksum(strings.TrimSuffix(base, filepath.Ext(base)))
+	if expected == "" {
+		return false, ErrNoChecksum
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+	return VerifyChecksum(file, expected)
+}
@@ -0,0 +1,45 @@
+package roflmeta
+
+import (
+	"errors"
+	"github.com/go-playground/assert/v2"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestVerifyChecksum(t *testing.T) {
+	ok, err := VerifyChecksum(strings.NewReader("hello"), "3610A686")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, ok, true)
+	ok, err = VerifyChecksum(strings.NewReader("hello"), "3610a686")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, ok, true)
+	ok, err = VerifyChecksum(strings.NewReader("hell0"), "3610A686")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, ok, false)
+	_, err = VerifyChecksum(strings.NewReader("hello"), "3610A68")
+	assert.Equal(t, errors.Is(err, ErrNoChecksum), true)
+}
+
+func TestVerifyFileChecksum(t *testing.T) {
+	dir := t.TempDir()
+	good := filepath.Join(dir, "[Group] Show - 01 [3610A686].mkv")
+	bad := filepath.Join(dir, "[Group] Show - 02 [3610A687].mkv")
+	missing := filepath.Join(dir, "[Group] Show - 03.mkv")
+	for _, name := range []string{good, bad, missing} {
+		if err := os.WriteFile(name, []byte("hello"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ok, err := VerifyFileChecksum(good)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, ok, true)
+	ok, err = VerifyFileChecksum(bad)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, ok, false)
+	_, err = VerifyFileChecksum(missing)
+	assert.Equal(t, errors.Is(err, ErrNoChecksum), true)
+}
@@ -0,0 +1,81 @@
+package roflmeta
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const cjkNumerals = "〇零一二三四五六七八九十百千两兩"
+
+var cjkEpisodeRegex = regexp.MustCompile("第\\s*(\\d+)\\s*[話话集回]")
+var cjkSeasonRegex = regexp.MustCompile("第\\s*(\\d+)\\s*[期季]")
+var cjkMarkerNumeralRegex = regexp.MustCompile("第\\s*([" + cjkNumerals + "]+)\\s*([話话集回期季])")
+
+var cjkDigits = map[rune]int{
+	'〇': 0, '零': 0, '一': 1, '二': 2, '两': 2, '兩': 2, '三': 3, '四': 4,
+	'五': 5, '六': 6, '七': 7, '八': 8, '九': 9,
+}
+
+var cjkMultipliers = map[rune]int{'十': 10, '百': 100, '千': 1000}
+
+// normalizeDigits replaces full-width digits with ASCII ones, e.g. "第１２話" becomes "第12話"
+func normalizeDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '０' && r <= '９' {
+			return '0' + (r - '０')
+		}
+		return r
+	}, s)
+}
+
+// parseCJKNumber parses kanji and hanzi numerals like "十二" or "二十五", as well as positional ones like "二〇"
+func parseCJKNumber(s string) (int, bool) {
+	if s == "" {
+		return 0, false
+	}
+	total := 0
+	current := -1
+	positional := 0
+	for _, r := range s {
+		if digit, ok := cjkDigits[r]; ok {
+			if current >= 0 {
+				// positional notation, e.g. "二〇"
+				positional = positional*10 + current
+			}
+			current = digit
+			continue
+		}
+		multiplier, ok := cjkMultipliers[r]
+		if !ok {
+			return 0, false
+		}
+		if current < 0 {
+			// "十" means 10, "十二" means 12
+			current = 1
+		}
+		total += current * multiplier
+		current = -1
+	}
+	if current >= 0 {
+		if total == 0 {
+			return positional*10 + current, true
+		}
+		total += current
+	}
+	return total, true
+}
+
+// normalizeCJK replaces full-width digits and numerals inside episode and season markers with ASCII digits,
+// e.g. "第１２話" and "第十二話" become "第12話", so that both single and multiple parsers see plain numbers
+func normalizeCJK(s string) string {
+	s = normalizeDigits(s)
+	return cjkMarkerNumeralRegex.ReplaceAllStringFunc(s, func(match string) string {
+		test := cjkMarkerNumeralRegex.FindStringSubmatch(match)
+		value, ok := parseCJKNumber(test[1])
+		if !ok {
+			return match
+		}
+		return "第" + strconv.Itoa(value) + test[2]
+	})
+}
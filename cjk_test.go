@@ -0,0 +1,32 @@
+package roflmeta
+
+import (
+	"github.com/go-playground/assert/v2"
+	"testing"
+)
+
+func assertCJKNumber(t *testing.T, s string, expected int) {
+	value, ok := parseCJKNumber(s)
+	assert.Equal(t, ok, true)
+	assert.Equal(t, value, expected)
+}
+
+func TestParseCJKNumber(t *testing.T) {
+	assertCJKNumber(t, "一", 1)
+	assertCJKNumber(t, "十", 10)
+	assertCJKNumber(t, "十二", 12)
+	assertCJKNumber(t, "二十", 20)
+	assertCJKNumber(t, "二十五", 25)
+	assertCJKNumber(t, "百二十", 120)
+	assertCJKNumber(t, "二〇", 20)
+	assertCJKNumber(t, "两", 2)
+	_, ok := parseCJKNumber("作品")
+	assert.Equal(t, ok, false)
+}
+
+func TestNormalizeCJK(t *testing.T) {
+	assert.Equal(t, normalizeCJK("第１２話"), "第12話")
+	assert.Equal(t, normalizeCJK("作品名 第十二話"), "作品名 第12話")
+	assert.Equal(t, normalizeCJK("第二季 第五集"), "第2季 第5集")
+	assert.Equal(t, normalizeCJK("一人之下"), "一人之下")
+}
@@ -0,0 +1,73 @@
+package roflmeta
+
+import "regexp"
+
+// ContentKind distinguishes regular episodes from specials, creditless openings, trailers, etc.
+type ContentKind int
+
+const (
+	KindRegular ContentKind = iota
+	KindSpecial
+	KindOVA
+	KindOpening
+	KindEnding
+	KindTrailer
+	KindExtra
+)
+
+type contentKindMarker struct {
+	kind  ContentKind
+	regex *regexp.Regexp
+}
+
+// short markers like OP or SP are case-sensitive, otherwise they match ordinary words too often
+// order matters, the first matching marker wins
+var contentKindMarkers = []contentKindMarker{
+	{KindOpening, regexp.MustCompile("(?:^|\\P{L})(?:NC)?OP\\d*(?:\\P{L}|$)|(?i)(?:^|\\P{L})(?:nc)?op\\d+(?:\\P{L}|$)|(?i)(?:^|\\P{L})(?:creditless )?opening(?:\\P{L}|$)")},
+	{KindEnding, regexp.MustCompile("(?:^|\\P{L})(?:NC)?ED\\d*(?:\\P{L}|$)|(?i)(?:^|\\P{L})nced\\d*(?:\\P{L}|$)|(?i)(?:^|\\P{L})(?:creditless )?ending(?:\\P{L}|$)")},
+	{KindTrailer, regexp.MustCompile("(?:^|\\P{L})(?:PV|CM)\\d*(?:\\P{L}|$)|(?i)(?:^|\\P{L})(?:preview|trailer|teaser)s?(?:\\P{L}|$)")},
+	{KindExtra, regexp.MustCompile("(?i)(?:^|\\P{L})(?:menu|extras?|bonus|making of|interview|sample)(?:\\P{L}|$)")},
+	{KindOVA, regexp.MustCompile("(?i)(?:^|\\P{L})(?:ova|oad|oav)s?(?:\\P{L}|$)")},
+	{KindSpecial, regexp.MustCompile("(?:^|\\P{L})SP\\d*(?:\\P{L}|$)|(?i)(?:^|\\P{L})(?:specials?|sp\\d+)(?:\\P{L}|$)")},
+}
+
+func (k ContentKind) String() string {
+	switch k {
+	case KindRegular:
+		return "regular"
+	case KindSpecial:
+		return "special"
+	case KindOVA:
+		return "OVA"
+	case KindOpening:
+		return "opening"
+	case KindEnding:
+		return "ending"
+	case KindTrailer:
+		return "trailer"
+	case KindExtra:
+		return "extra"
+	}
+	return "unknown"
+}
+
+// specialSeason is the season of specials and OVAs, as in most media libraries
+const specialSeason = "0"
+
+// applySpecialSeason files specials and OVAs under season 0, e.g. "Show - S01SP1" is season 0
+func applySpecialSeason(result *EpisodeMetadata) {
+	if result.Kind == KindSpecial || result.Kind == KindOVA {
+		result.Season = specialSeason
+	}
+}
+
+// detectContentKind searches for special markers in the filename without path and extension
+func detectContentKind(base string) ContentKind {
+	spaced := delimiterRegex.ReplaceAllLiteralString(base, " ")
+	for _, marker := range contentKindMarkers {
+		if marker.regex.MatchString(spaced) {
+			return marker.kind
+		}
+	}
+	return KindRegular
+}
@@ -0,0 +1,45 @@
+package roflmeta
+
+import (
+	"github.com/go-playground/assert/v2"
+	"testing"
+)
+
+func TestContentKindRegular(t *testing.T) {
+	assert.Equal(t, detectContentKind("[Judas] Hunter x Hunter (2011) - S01E012"), KindRegular)
+	assert.Equal(t, detectContentKind("[DB]Nekomonogatari (Black)_-_Recap01_(10bit_BD1080p_x265)"), KindRegular)
+	assert.Equal(t, detectContentKind("Fullmetal Alchemist - 05 - Ed and Al"), KindRegular)
+	assert.Equal(t, detectContentKind("Hellsing - Ep. 05 - Brotherhood (480p DVDRip - DUAL Audio)"), KindRegular)
+}
+
+func TestContentKindSpecial(t *testing.T) {
+	assert.Equal(t, detectContentKind("[Judas] Jujutsu Kaisen - S01SP1"), KindSpecial)
+	assert.Equal(t, detectContentKind("The Tatami Galaxy - Special E01 [1080p][x265][10-bit]"), KindSpecial)
+	assert.Equal(t, detectContentKind("Show - SP 2"), KindSpecial)
+}
+
+func TestContentKindOVA(t *testing.T) {
+	assert.Equal(t, detectContentKind("[Underwater] Panty and Stocking with Garterbelt OVA - In Sanitarybox"), KindOVA)
+	assert.Equal(t, detectContentKind("Shingeki No Kyojin Oad 03"), KindOVA)
+}
+
+func TestContentKindOpeningEnding(t *testing.T) {
+	assert.Equal(t, detectContentKind("[DB]Kabukimonogatari_-_NCED01_(10bit_BD1080p_x265)"), KindEnding)
+	assert.Equal(t, detectContentKind("[DB]Kabukimonogatari_-_NCOP2_(10bit_BD1080p_x265)"), KindOpening)
+	assert.Equal(t, detectContentKind("[Reaktor] The Tatami Galaxy OP [1080p][x265][10-bit]"), KindOpening)
+	assert.Equal(t, detectContentKind("[Reaktor] The Tatami Galaxy ED [1080p][x265][10-bit]"), KindEnding)
+	assert.Equal(t, detectContentKind("Show - Creditless Opening"), KindOpening)
+}
+
+func TestContentKindTrailerExtra(t *testing.T) {
+	assert.Equal(t, detectContentKind("Show - PV1"), KindTrailer)
+	assert.Equal(t, detectContentKind("Show - Preview 05"), KindTrailer)
+	assert.Equal(t, detectContentKind("[BD] Show - Menu02"), KindExtra)
+	assert.Equal(t, detectContentKind("Show Extras - Interview"), KindExtra)
+}
+
+func TestContentKindSeason(t *testing.T) {
+	assert.Equal(t, ParseSingleEpisodeMetadata("[Judas] Jujutsu Kaisen - S01SP1.mkv").Season, "0")
+	assert.Equal(t, ParseSingleEpisodeMetadata("Shingeki No Kyojin S03 OVA 2.mkv").Season, "0")
+	assert.Equal(t, ParseSingleEpisodeMetadata("Show - S02E00 - NCOP1.mkv").Season, "02")
+}
@@ -0,0 +1,135 @@
+package roflmeta
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// bare "s3" is a season only if it is the whole name, e.g. not "/mnt/s3", but zero padded "Show S03" is fine
+var dirSeasonRegex = regexp.MustCompile("(?i)(?:^|\\s)(?:season|series)\\s*(\\d{1,3})(?:\\s|$)|^s(\\d{1,3})$|(?:^|\\s)S(0\\d|\\d{3})(?:\\s|$)")
+var dirSpecialsRegex = regexp.MustCompile("(?i)^(?:specials?|sp|ova|ovas|season\\s*0+|s0+)$")
+var dirExtrasRegex = regexp.MustCompile("(?i)^(?:extras?|bonus|featurettes?|behind the scenes|menus?|trailers?|nc|ncop|nced|ncop ?& ?nced|cm|pv|scans)$")
+
+const specialsSeason = "0"
+
+// maxDirLevels is the number of the closest directories that are taken into account
+const maxDirLevels = 3
+
+// dirInfo is the metadata found in the directory names of a file
+type dirInfo struct {
+	title  string
+	season string
+	part   int
+	kind   ContentKind
+}
+
+// cleanDirComponent removes tags and delimiters, e.g. "[Judas] Show_S2 (1080p)" becomes "Show S2"
+func cleanDirComponent(name string) string {
+	name = normalizeCJK(name)
+	name = bracketRemoveRegex.ReplaceAllLiteralString(name, " ")
+	name = delimiterRegex.ReplaceAllLiteralString(name, " ")
+	if !strings.Contains(strings.TrimSpace(name), " ") {
+		name = strings.ReplaceAll(name, ".", " ")
+	}
+	return strings.TrimSpace(name)
+}
+
+// parseDirComponent parses a single directory name, e.g. "Season 02", "S2", "Dr Stone Season 2", "Specials" or "Extras"
+func (p *Parser) parseDirComponent(name string) dirInfo {
+	cleaned := cleanDirComponent(name)
+	if dirSpecialsRegex.MatchString(cleaned) {
+		return dirInfo{season: specialsSeason, kind: KindSpecial}
+	}
+	if dirExtrasRegex.MatchString(cleaned) {
+		return dirInfo{kind: KindExtra}
+	}
+
+	result := dirInfo{}
+	result.part, _ = parsePart(cleaned)
+	season, match := "", ""
+	if test := dirSeasonRegex.FindStringSubmatch(cleaned); test != nil {
+		season, match = canonicalNumber(test[1]+test[2]+test[3]), test[0]
+	} else if season, match = parseSeasonWord(cleaned); match != "" {
+		season = canonicalNumber(season)
+	} else if test := cjkSeasonRegex.FindStringSubmatch(cleaned); test != nil {
+		season, match = canonicalNumber(test[1]), test[0]
+	} else {
+		localized := p.languageRegexes()
+		season, match = findKeywordNumber(cleaned, localized.seasonForward, localized.seasonBackward)
+		season = canonicalNumber(season)
+	}
+	if match != "" {
+		result.season = season
+		result.title = titleBefore(cleaned, match)
+		return result
+	}
+	result.title = cleanTitle(cleaned)
+	return result
+}
+
+// isStructural checks whether the directory only organizes files of a show, e.g. "Season 2", "Part 2" or "Extras"
+func (info dirInfo) isStructural() bool {
+	return info.title == "" && (info.season != "" || info.part > 0 || info.kind != KindRegular)
+}
+
+// parseDirHierarchy walks up the closest directories of the path, the closest directory to the file wins,
+// e.g. "Show/Season 2/Extras/file.mkv" is an extra of season 2 of "Show"
+// The walk stops at the first directory that is not a season, part, specials or extras directory, its name is a title
+// only if it contains a season marker or is right above such directories, so that "/home/user/Downloads/05.mkv"
+// has no title
+func (p *Parser) parseDirHierarchy(path string) dirInfo {
+	result := dirInfo{}
+	dir := filepath.Dir(path)
+	aboveStructural := false
+	for level := 0; level < maxDirLevels && dir != "." && dir != "/" && dir != filepath.Dir(dir); level++ {
+		info := p.parseDirComponent(filepath.Base(dir))
+		if result.season == "" {
+			result.season = info.season
+		}
+		if result.part == 0 {
+			result.part = info.part
+		}
+		if result.kind == KindRegular {
+			result.kind = info.kind
+		}
+		if !info.isStructural() {
+			if info.season != "" || aboveStructural {
+				result.title = info.title
+			}
+			break
+		}
+		aboveStructural = true
+		dir = filepath.Dir(dir)
+	}
+	return result
+}
+
+// dirSeasons returns seasons of the directories if each one has a season marker, e.g. "S1" and "Season 2"
+func dirSeasons(dirs []string, dirInfoMap map[string]dirInfo) (map[string]string, bool) {
+	result := make(map[string]string, len(dirs))
+	for _, dir := range dirs {
+		season := dirInfoMap[dir].season
+		if season == "" {
+			return nil, false
+		}
+		result[dir] = season
+	}
+	return result, true
+}
+
+// applyDirInfo fills the gaps of the filename result, filename always takes precedence
+func applyDirInfo(result *EpisodeMetadata, info dirInfo) {
+	if result.Title == "" {
+		result.Title = info.title
+	}
+	if result.Season == "" {
+		result.Season = info.season
+	}
+	if result.Part == 0 {
+		result.Part = info.part
+	}
+	if result.Kind == KindRegular {
+		result.Kind = info.kind
+	}
+}
@@ -0,0 +1,30 @@
+package roflmeta
+
+import (
+	"github.com/go-playground/assert/v2"
+	"testing"
+)
+
+func TestParseDirComponent(t *testing.T) {
+	assert.Equal(t, defaultParser.parseDirComponent("Season 02"), dirInfo{season: "2"})
+	assert.Equal(t, defaultParser.parseDirComponent("S2"), dirInfo{season: "2"})
+	assert.Equal(t, defaultParser.parseDirComponent("Dr Stone Season 2"), dirInfo{title: "Dr Stone", season: "2"})
+	assert.Equal(t, defaultParser.parseDirComponent("[Judas] Show.S03"), dirInfo{title: "Show", season: "3"})
+	assert.Equal(t, defaultParser.parseDirComponent("Specials"), dirInfo{season: specialsSeason, kind: KindSpecial})
+	assert.Equal(t, defaultParser.parseDirComponent("Season 00"), dirInfo{season: specialsSeason, kind: KindSpecial})
+	assert.Equal(t, defaultParser.parseDirComponent("Extras"), dirInfo{kind: KindExtra})
+	assert.Equal(t, defaultParser.parseDirComponent("[Judas] Hunter x Hunter (2011) - Movies"), dirInfo{title: "Hunter x Hunter"})
+	assert.Equal(t, defaultParser.parseDirComponent("s3"), dirInfo{season: "3"})
+	assert.Equal(t, defaultParser.parseDirComponent("Show s3"), dirInfo{title: "Show s3"})
+}
+
+func TestParseDirHierarchy(t *testing.T) {
+	assert.Equal(t, defaultParser.parseDirHierarchy("Show/Season 2/05.mkv"), dirInfo{title: "Show", season: "2"})
+	assert.Equal(t, defaultParser.parseDirHierarchy("TV/Show/Season 2/Extras/Interview.mkv"), dirInfo{title: "Show", season: "2", kind: KindExtra})
+	assert.Equal(t, defaultParser.parseDirHierarchy("Show/Final Season Part 2/05.mkv"), dirInfo{title: "Show", season: finalSeason, part: 2})
+	assert.Equal(t, defaultParser.parseDirHierarchy("05.mkv"), dirInfo{})
+	assert.Equal(t, defaultParser.parseDirHierarchy("/home/alice/Downloads/05.mkv"), dirInfo{})
+	assert.Equal(t, defaultParser.parseDirHierarchy("/mnt/s3/anime/Show - 05.mkv"), dirInfo{})
+	assert.Equal(t, defaultParser.parseDirHierarchy("/mnt/media/Show/Season 2/05.mkv"), dirInfo{title: "Show", season: "2"})
+	assert.Equal(t, defaultParser.parseDirHierarchy("/mnt/Show/Season 2/Extras/Specials/05.mkv"), dirInfo{season: specialsSeason, kind: KindSpecial})
+}
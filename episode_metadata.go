@@ -1,15 +1,67 @@
 package roflmeta
 
+import "time"
+
 // EpisodeMetadata best attempt at extracting metadata from filename alone
 // SHOULD follow these rules:
-// * Season should either be a show title, a season name/number or empty if provided filename lacks information
+// * Title should be a show title or empty if provided filename lacks information
+// * Season should either be a season name/number or empty if provided filename lacks information
 // * Episode should be as short as possible, usually 0*\\d+ or non-numerical episode name
 //
 // MUST follow these rules:
 // * Episode MUST be displayable to end user
 // * Episode MUST NOT be blank for video files
-// * Episode MUST BE BLANK for non-video files (as well as season)
+// * Episode MUST BE BLANK for non-video files (as well as title and season)
 type EpisodeMetadata struct {
+	Title   string
 	Season  string
 	Episode string
+	// EpisodeEnd is the last episode of a range if the file contains multiple episodes, e.g. S01E01-E02
+	// It is empty for single episode files
+	EpisodeEnd string
+	// AbsoluteEpisode is the episode index counted across all seasons, 0 if unknown
+	// It is only calculated by ParseMultipleEpisodeMetadata
+	AbsoluteEpisode int
+	// Interstitial is true for recaps and half episodes like 12.5 that are aired between regular episodes
+	Interstitial bool
+	// Kind tells regular episodes apart from specials, OVAs, creditless openings/endings, trailers and extras
+	Kind ContentKind
+	// AirDate is the broadcast date of daily shows, e.g. "Show.2023.05.14.Guest.Name", zero if not found
+	// Episode is the date formatted as 2006-01-02 in that case, unless season and episode are present too
+	AirDate time.Time
+	// Part is the part or cour of a split season, e.g. 2 for "Final Season Part 2", 0 if not found
+	Part int
+	// Year is the release or show year, e.g. 2011 for "Hunter x Hunter (2011)", 0 if not found
+	Year int
+	// Version is the re-release version, e.g. 2 for "Show - 05v2", 0 if not specified
+	Version int
+	// Release contains release group, resolution, codecs and source found in filename
+	Release ReleaseInfo
+	// Confidence is in range [0, 1], higher values mean the result is more trustworthy
+	// It is always 0 for non-video files
+	Confidence float64
 }
+
+const (
+	confidenceSxE             = 0.85
+	confidenceES              = 0.9
+	confidenceSE              = 0.95
+	confidenceCJK             = 0.9
+	confidenceAirDate         = 0.9
+	confidenceEp              = 0.75
+	confidenceEpisode         = 0.85
+	confidenceDotSpace        = 0.7
+	confidenceBracketNumber   = 0.6
+	confidenceStartsWithNum   = 0.6
+	confidenceNumberWithSpace = 0.5
+	confidenceLastResort      = 0.3
+	confidenceSeasonAsEpisode = 0.2
+	confidenceBaseName        = 0.1
+
+	confidenceTemplateEpisodes = 0.9
+	confidenceTemplateSeasons  = 0.8
+	// templates of one or two files are less reliable
+	confidenceFewFilesFactor = 0.8
+	// the template is known in advance
+	confidenceNamingTemplate = 1
+)
@@ -5,21 +5,72 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 var spaceRegex = regexp.MustCompile("^\\s*$")
 var bracketRemoveRegex = regexp.MustCompile("\\[.*?\\]|\\(.*?\\)|\\{.*?\\}")
+var numberRunRegex = regexp.MustCompile("\\d+(?:\\.\\d+)?")
 
 type frequency struct {
 	value int
 	group int
 }
 
+// DirMethod describes how files of a single directory were parsed by ParseMultipleEpisodeMetadata
+type DirMethod int
+
+const (
+	// DirMethodTemplate means filename template was restored and used
+	DirMethodTemplate DirMethod = iota
+	// DirMethodFallback means template method failed and single file parser was used instead
+	DirMethodFallback
+	// DirMethodSkipped means template method wasn't attempted, e.g. directory contains a single video file
+	DirMethodSkipped
+	// DirMethodAirDate means most files contain distinct air dates, which were used as episodes
+	DirMethodAirDate
+)
+
+func (m DirMethod) String() string {
+	switch m {
+	case DirMethodTemplate:
+		return "template"
+	case DirMethodFallback:
+		return "fallback"
+	case DirMethodSkipped:
+		return "skipped"
+	case DirMethodAirDate:
+		return "air date"
+	}
+	return "unknown"
+}
+
+// DirOutcome is the result of processing a single directory
+type DirOutcome struct {
+	Dir    string
+	Method DirMethod
+	// Err is one of ErrInvalidTemplate, ErrRegexFailed or ErrMultipleFailed if Method is DirMethodFallback
+	Err error
+	// Template is the learned template if Method is DirMethodTemplate, it can be saved to parse new files later
+	Template *LearnedTemplate
+	// Outliers are the files that don't follow the template, e.g. a stray "Sample.mkv",
+	// or files without a date if Method is DirMethodAirDate, they are parsed by the single file parser
+	Outliers []string
+}
+
 type fileEntry struct {
+	filename        string
+	base            string
 	cleanedFileName string
 	dir             string
 	isVideo         bool
+	kind            ContentKind
+	version         int
+	dirInfo         dirInfo
 	result          EpisodeMetadata
+	// seasonFromTitle is true if title is used in place of a missing season
+	seasonFromTitle bool
 }
 
 func preCleanFileName(filename string) string {
@@ -40,7 +91,7 @@ func calcRegexFrequencies(filenames []string, regex *regexp.Regexp, groupCount i
 		for _, name := range filenames {
 			test := regex.FindStringSubmatch(name)
 			if test == nil {
-				return nil, errRegexFailed
+				return nil, ErrRegexFailed
 			}
 			// don't count empty matches
 			if !spaceRegex.MatchString(test[group]) {
@@ -85,36 +136,72 @@ func parseChangingDirs(dirs []string, regex *regexp.Regexp) map[string]string {
 	return result
 }
 
-func parseChangingEpisodes(filenames []string, testSeasonFilename string, regex *regexp.Regexp, episodeGroup int) []EpisodeMetadata {
+func (p *Parser) parseChangingEpisodes(filenames []string, testSeasonFilename string, regex *regexp.Regexp, episodeGroup int) []EpisodeMetadata {
 	result := make([]EpisodeMetadata, 0, len(filenames))
 	// will trust try-hard single episode parser on this one
-	season := ParseSingleEpisodeMetadata(testSeasonFilename).Season
+	single := p.parseSingle(testSeasonFilename, nil)
 	for _, name := range filenames {
 		test := regex.FindStringSubmatch(name)
+		episode, episodeEnd := splitEpisodeRange(postCleanData(test[episodeGroup]))
 		result = append(result, EpisodeMetadata{
-			Episode: postCleanData(test[episodeGroup]),
-			Season:  season,
+			Episode:    episode,
+			EpisodeEnd: episodeEnd,
+			Title:      single.Title,
+			Season:     single.Season,
+			Confidence: confidenceTemplateEpisodes,
 		})
 	}
 	return result
 }
 
-func parseEpisodesAndSeasons(filenames []string, regex *regexp.Regexp, seasonGroup int, episodeGroup int) []EpisodeMetadata {
+func (p *Parser) parseEpisodesAndSeasons(filenames []string, t *template, seasonGroup int, episodeGroup int) []EpisodeMetadata {
+	regex := t.toRegex()
 	result := make([]EpisodeMetadata, 0, len(filenames))
+	// changing part is not a season, e.g. "Show Final Season Part 1 - 12" and "Show Final Season Part 2 - 01"
+	isPart := isPartGroup(filenames[0], regex, seasonGroup)
+	// the title is the same for all files if it lies in the constant beginning of the template
+	single := p.parseSingle(filenames[0], nil)
+	sameTitle := single.Title != "" && strings.Contains(t.basePrefix(), single.Title)
 	for _, name := range filenames {
 		test := regex.FindStringSubmatch(name)
-		result = append(result, EpisodeMetadata{
-			Episode: postCleanData(test[episodeGroup]),
-			Season:  postCleanData(test[seasonGroup]),
-		})
+		episode, episodeEnd := splitEpisodeRange(postCleanData(test[episodeGroup]))
+		if !sameTitle {
+			single = p.parseSingle(name, nil)
+		}
+		metadata := EpisodeMetadata{
+			Episode:    episode,
+			EpisodeEnd: episodeEnd,
+			Title:      single.Title,
+			Season:     postCleanData(test[seasonGroup]),
+			Confidence: confidenceTemplateSeasons,
+		}
+		if isPart {
+			metadata.Season = single.Season
+			metadata.Part = templatePart(test[seasonGroup])
+		}
+		result = append(result, metadata)
+	}
+	return result
+}
+
+func (p *Parser) fallbackToSingleParser(filenames []string, explanation *DirExplanation) []EpisodeMetadata {
+	result := p.parseWithSingleParser(filenames, explanation)
+	if explanation != nil {
+		explanation.Fallback = true
 	}
 	return result
 }
 
-func fallbackToSingleParser(filenames []string) []EpisodeMetadata {
+func (p *Parser) parseWithSingleParser(filenames []string, explanation *DirExplanation) []EpisodeMetadata {
 	result := make([]EpisodeMetadata, 0, len(filenames))
 	for _, name := range filenames {
-		result = append(result, ParseSingleEpisodeMetadata(name))
+		if explanation == nil {
+			result = append(result, p.parseSingle(name, nil))
+			continue
+		}
+		fileExplanation := Explanation{Filename: name}
+		result = append(result, p.parseSingle(name, &fileExplanation))
+		explanation.Files = append(explanation.Files, fileExplanation)
 	}
 	return result
 }
@@ -140,21 +227,344 @@ func getSeasons(dirFileMap map[string][]*fileEntry) []string {
 
 func getDirs(dirFileMap map[string][]*fileEntry) []string {
 	dirs := make([]string, 0, len(dirFileMap))
-	for dir, _ := range dirFileMap {
+	for dir := range dirFileMap {
 		dirs = append(dirs, dir)
 	}
+	sort.Strings(dirs)
 	return dirs
 }
 
-func parseMultipleEpisodeMetadataImpl(filenames []string) ([]EpisodeMetadata, error) {
-	if len(filenames) == 1 {
-		return fallbackToSingleParser(filenames), nil
+func getCleanedFileNames(entries []*fileEntry) []string {
+	result := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, entry.cleanedFileName)
+	}
+	return result
+}
+
+// splitEntries splits entries into the ones not listed in indices and the listed ones, indices are sorted
+func splitEntries(entries []*fileEntry, indices []int) ([]*fileEntry, []*fileEntry) {
+	rest := make([]*fileEntry, 0, len(entries)-len(indices))
+	listed := make([]*fileEntry, 0, len(indices))
+	for i, entry := range entries {
+		if len(listed) < len(indices) && indices[len(listed)] == i {
+			listed = append(listed, entry)
+		} else {
+			rest = append(rest, entry)
+		}
+	}
+	return rest, listed
+}
+
+func setResults(entries []*fileEntry, results []EpisodeMetadata) {
+	for i, r := range results {
+		entries[i].result = r
+	}
+}
+
+// parseDir fills results of entries located in a single directory
+func (p *Parser) parseDir(dir string, entries []*fileEntry, explanation *DirExplanation) DirOutcome {
+	outcome := DirOutcome{Dir: dir}
+	dirFilenames := getCleanedFileNames(entries)
+	if len(dirFilenames) == 1 {
+		outcome.Method = DirMethodSkipped
+		setResults(entries, p.fallbackToSingleParser(dirFilenames, explanation))
+		return outcome
+	}
+
+	// daily shows skip the template, its variables would only capture the changing parts of the dates,
+	// e.g. "Show.2023.05.*", while the single parser reads the whole date of each file
+	if undated, ok := splitAirDateBatch(entries); ok {
+		outcome.Method = DirMethodAirDate
+		for _, entry := range undated {
+			outcome.Outliers = append(outcome.Outliers, entry.filename)
+		}
+		setResults(entries, p.parseWithSingleParser(dirFilenames, explanation))
+		return outcome
+	}
+
+	// specials and extras are often named differently, which breaks the template
+	regular := make([]*fileEntry, 0, len(entries))
+	special := make([]*fileEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.kind == KindRegular {
+			regular = append(regular, entry)
+		} else {
+			special = append(special, entry)
+		}
+	}
+
+	t, err := restoreTemplate(dirFilenames)
+	var result []EpisodeMetadata
+	var roles templateRoles
+	if err == nil {
+		result, roles, err = p.parseWithTemplate(t, dirFilenames, explanation)
+	}
+	// e.g. "Show - 01.mkv" and "Sample.mkv" give "S*.mkv", whose values are "how - 01" and "ample"
+	if err == nil && templateSplitsWords(t, roles, dirFilenames) {
+		err = ErrInvalidTemplate
+	}
+	if err == nil && len(special) == 0 {
+		// a file may still follow the template, but have values unlike the others, e.g. a typo "-NTB" among "-NTb"
+		// or "04 FINAL CUT director" among numbers, such a variable is not a season or episode of the majority
+		minority := minorityValueFiles(t, roles, dirFilenames)
+		if len(minority) > 0 && len(minority) <= maxOutlierCount(len(dirFilenames)) {
+			conforming, _ := splitEntries(entries, minority)
+			subsetTemplate, subsetErr := restoreTemplate(getCleanedFileNames(conforming))
+			if subsetErr == nil && p.parseWithOutliers(&outcome, subsetTemplate, entries, minority, explanation) {
+				return outcome
+			}
+		}
+		if t.isSpecific(dirFilenames) {
+			outcome.Method = DirMethodTemplate
+			outcome.Template = newLearnedTemplate(t, roles, dirFilenames)
+			setResults(entries, result)
+			return outcome
+		}
+	}
+
+	// retry without specials, they are parsed by the full template if it's good enough or by the single parser
+	if len(special) > 0 && len(regular) > 1 {
+		var fullResult []EpisodeMetadata
+		if err == nil && t.isSpecific(dirFilenames) {
+			fullResult = result
+		}
+		if learned, outliers, ok := p.parseRegularOnly(entries, regular, special, fullResult, explanation); ok {
+			outcome.Method = DirMethodTemplate
+			outcome.Template = learned
+			for _, entry := range outliers {
+				outcome.Outliers = append(outcome.Outliers, entry.filename)
+			}
+			return outcome
+		}
+	}
+
+	// stray files break the template, the rest is parsed with the template of the majority
+	acceptSubset := func(subsetTemplate *template, outlierIndices []int) bool {
+		return p.parseWithOutliers(&outcome, subsetTemplate, entries, outlierIndices, explanation)
+	}
+	if _, _, subsetErr := restoreTemplateWithOutliers(dirFilenames, t, acceptSubset); subsetErr == nil {
+		return outcome
 	}
 
-	t, err := restoreTemplate(filenames)
+	// template may not describe much, but it is still better than nothing
+	if err == nil {
+		outcome.Method = DirMethodTemplate
+		outcome.Template = newLearnedTemplate(t, roles, dirFilenames)
+		setResults(entries, result)
+		return outcome
+	}
 
+	outcome.Method = DirMethodFallback
+	outcome.Err = err
+	setResults(entries, p.fallbackToSingleParser(dirFilenames, explanation))
+	return outcome
+}
+
+// parseWithOutliers parses entries with the template of the subset that doesn't include outliers,
+// outliers are parsed by the single file parser, indices of outliers are sorted
+// Returns false and leaves entries and outcome intact if the template can't parse the subset
+func (p *Parser) parseWithOutliers(outcome *DirOutcome, t *template, entries []*fileEntry, outlierIndices []int, explanation *DirExplanation) bool {
+	conforming, outliers := splitEntries(entries, outlierIndices)
+	conformingFilenames := getCleanedFileNames(conforming)
+	if !t.isSpecific(conformingFilenames) {
+		return false
+	}
+	result, roles, err := p.parseWithTemplate(t, conformingFilenames, nil)
 	if err != nil {
-		return nil, err
+		return false
+	}
+	// only the accepted attempt is explained
+	_, _ = findTemplateRoles(t, conformingFilenames, explanation)
+	outcome.Method = DirMethodTemplate
+	outcome.Template = newLearnedTemplate(t, roles, conformingFilenames)
+	for _, entry := range outliers {
+		outcome.Outliers = append(outcome.Outliers, entry.filename)
+	}
+	setResults(conforming, result)
+	setResults(outliers, p.fallbackToSingleParser(getCleanedFileNames(outliers), explanation))
+	return true
+}
+
+// valueShape replaces numbers with '0', runs of upper and lower case letters with 'A' and 'a',
+// and runs of other letters with 'L', e.g. "Final Cut 12.5" becomes "Aa Aa 0"
+func valueShape(value string) string {
+	value = numberRunRegex.ReplaceAllLiteralString(value, "0")
+	var result strings.Builder
+	last := rune(0)
+	for _, r := range value {
+		class := r
+		switch {
+		case unicode.IsDigit(r):
+			class = '0'
+		case unicode.IsUpper(r):
+			class = 'A'
+		case unicode.IsLower(r):
+			class = 'a'
+		case unicode.IsLetter(r):
+			class = 'L'
+		case unicode.IsSpace(r):
+			class = ' '
+		}
+		// punctuation is kept as is, e.g. "12-13"
+		if class != last || !strings.ContainsRune("0AaL ", class) {
+			result.WriteRune(class)
+		}
+		last = class
+	}
+	return result.String()
+}
+
+// minorityValueFiles returns indices of files whose season or episode values are shaped unlike the values
+// of most files, e.g. "B" among "b" or "4 FINAL CUT director" among numbers, see valueShape
+func minorityValueFiles(t *template, roles templateRoles, filenames []string) []int {
+	regex := t.toRegex()
+	tests := make([][]string, 0, len(filenames))
+	for _, name := range filenames {
+		tests = append(tests, regex.FindStringSubmatch(name))
+	}
+	minority := make(map[int]struct{})
+	for _, group := range []int{roles.seasonGroup, roles.episodeGroup} {
+		if group == 0 {
+			continue
+		}
+		shapes := make([]string, len(tests))
+		counts := make(map[string]int)
+		for i, test := range tests {
+			// ranges like "12-13" are read as their first episode
+			value, _ := splitEpisodeRange(postCleanData(test[group]))
+			shapes[i] = valueShape(value)
+			counts[shapes[i]]++
+		}
+		common := ""
+		for shape, count := range counts {
+			if count > counts[common] || (count == counts[common] && shape < common) {
+				common = shape
+			}
+		}
+		if counts[common]*2 <= len(tests) {
+			continue
+		}
+		for i, shape := range shapes {
+			if shape != common {
+				minority[i] = struct{}{}
+			}
+		}
+	}
+	result := make([]int, 0, len(minority))
+	for i := range tests {
+		if _, ok := minority[i]; ok {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+// templateSplitsWords checks whether a season or episode value of some file starts in the middle of a word
+func templateSplitsWords(t *template, roles templateRoles, filenames []string) bool {
+	regex := t.toRegex()
+	for _, name := range filenames {
+		loc := regex.FindStringSubmatchIndex(name)
+		for _, group := range []int{roles.seasonGroup, roles.episodeGroup} {
+			if group == 0 || loc[2*group] <= 0 || loc[2*group] == loc[2*group+1] {
+				continue
+			}
+			before, _ := utf8.DecodeLastRuneInString(name[:loc[2*group]])
+			first, _ := utf8.DecodeRuneInString(name[loc[2*group]:])
+			if unicode.IsLetter(before) && unicode.IsLetter(first) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseRegularOnly parses regular episodes with their own template, returns false if there is no specific one
+// Specials are named differently and make the full template too broad, e.g. "S01SP1" next to "S01E01"
+// leaves "E01" as the episode value of "S01*", so the template of regular episodes gives cleaner episodes
+// Specials keep results of the full template if it is specific, fullResult is nil otherwise
+// Returns specials that don't follow the template, they are parsed by the single file parser
+func (p *Parser) parseRegularOnly(entries []*fileEntry, regular []*fileEntry, special []*fileEntry, fullResult []EpisodeMetadata, explanation *DirExplanation) (*LearnedTemplate, []*fileEntry, bool) {
+	regularFilenames := getCleanedFileNames(regular)
+	regularTemplate, err := restoreTemplate(regularFilenames)
+	if err != nil || !regularTemplate.isSpecific(regularFilenames) {
+		return nil, nil, false
+	}
+	regularResult, regularRoles, err := p.parseWithTemplate(regularTemplate, regularFilenames, explanation)
+	if err != nil {
+		return nil, nil, false
+	}
+	unmatched := special
+	if fullResult != nil {
+		// keep seasons of the full template, so that openings and extras stay in the same season
+		setResults(entries, fullResult)
+		for i, entry := range regular {
+			entry.result.Episode = regularResult[i].Episode
+			entry.result.EpisodeEnd = regularResult[i].EpisodeEnd
+		}
+		// a special may match the full template only by chance, e.g. "Sample.mkv" next to "Show - 01.mkv" gives "ample"
+		unmatched = nil
+		for _, entry := range special {
+			if splitsWord(entry.cleanedFileName, entry.result.Episode) {
+				unmatched = append(unmatched, entry)
+			}
+		}
+	} else {
+		setResults(regular, regularResult)
+	}
+	if len(unmatched) > 0 {
+		setResults(unmatched, p.fallbackToSingleParser(getCleanedFileNames(unmatched), explanation))
+	}
+	return newLearnedTemplate(regularTemplate, regularRoles, regularFilenames), unmatched, true
+}
+
+// templateRoles are groups of the template regex holding season and episode, seasonGroup is 0 if season doesn't change
+type templateRoles struct {
+	seasonGroup  int
+	episodeGroup int
+}
+
+func (p *Parser) parseWithTemplate(t *template, filenames []string, explanation *DirExplanation) ([]EpisodeMetadata, templateRoles, error) {
+	roles, err := findTemplateRoles(t, filenames, explanation)
+	if err != nil {
+		return nil, roles, err
+	}
+	regex := t.toRegex()
+	var result []EpisodeMetadata
+	if roles.seasonGroup == 0 {
+		result = p.parseChangingEpisodes(filenames, filenames[0], regex, roles.episodeGroup)
+	} else {
+		result = p.parseEpisodesAndSeasons(filenames, t, roles.seasonGroup, roles.episodeGroup)
+	}
+	// episode must never be blank, template is wrong if it happens
+	for _, r := range result {
+		if r.Episode == "" {
+			return nil, roles, ErrMultipleFailed
+		}
+	}
+	factor := templateConfidenceFactor(t, filenames)
+	for i := range result {
+		result[i].Confidence *= factor
+	}
+	return result, roles, nil
+}
+
+// templateConfidenceFactor lowers confidence of templates that describe little of the filenames
+// or were restored from too few files to tell constant text from a coincidence
+func templateConfidenceFactor(t *template, filenames []string) float64 {
+	factor := 1.0
+	if specificity := t.specificity(filenames); specificity < 1 {
+		factor = specificity
+	}
+	if len(filenames) < minOutlierFiles {
+		factor *= confidenceFewFilesFactor
+	}
+	return factor
+}
+
+func findTemplateRoles(t *template, filenames []string, explanation *DirExplanation) (templateRoles, error) {
+	if explanation != nil {
+		explanation.Template = t.String()
 	}
 
 	varCount := t.varCount()
@@ -162,13 +572,14 @@ func parseMultipleEpisodeMetadataImpl(filenames []string) ([]EpisodeMetadata, er
 
 	// definitely a single season with changing episodes
 	if varCount == 1 {
-		return parseChangingEpisodes(filenames, filenames[0], regex, 1), nil
+		return templateRoles{episodeGroup: 1}, nil
 	}
 
 	frequencies, err := calcRegexFrequencies(filenames, regex, varCount)
 	if err != nil {
-		return nil, err
+		return templateRoles{}, err
 	}
+	explanation.setFrequencies(frequencies)
 
 	distinctFreqCount := calcDistinctFrequencies(frequencies)
 	groupMonotonous := testFreqGroupMonotonous(frequencies)
@@ -183,36 +594,124 @@ func parseMultipleEpisodeMetadataImpl(filenames []string) ([]EpisodeMetadata, er
 	if groupMonotonous {
 		// definitely only episodes
 		if distinctFreqCount == 1 {
-			return parseChangingEpisodes(filenames, filenames[0], regex, frequencies[len(frequencies)-1].group), nil
+			return templateRoles{episodeGroup: frequencies[len(frequencies)-1].group}, nil
 		}
 		// probably seasons and episodes
 		if distinctFreqCount == 2 {
-			return parseEpisodesAndSeasons(filenames, regex, frequencies[len(frequencies)-2].group, frequencies[len(frequencies)-1].group), nil
+			return templateRoles{
+				seasonGroup:  frequencies[len(frequencies)-2].group,
+				episodeGroup: frequencies[len(frequencies)-1].group,
+			}, nil
 		}
 	}
 
-	return nil, errMultipleFailed
+	return templateRoles{}, ErrMultipleFailed
 }
 
 // ParseMultipleEpisodeMetadata attempts to parse metadata from multiple filenames
 // See EpisodeMetadata for details
 // It tries to figure out filenames' template and gather information according to it
 func ParseMultipleEpisodeMetadata(filenames []string) []EpisodeMetadata {
+	return defaultParser.ParseMultiple(filenames)
+}
+
+// ParseMultipleEpisodeMetadataWithOutcomes works exactly like ParseMultipleEpisodeMetadata,
+// but also reports how files of each directory were parsed
+// Returned error is ErrSingleParserOnly if neither the template method nor air dates were used for any directory,
+// results are still valid in that case
+func ParseMultipleEpisodeMetadataWithOutcomes(filenames []string) ([]EpisodeMetadata, []DirOutcome, error) {
+	return defaultParser.ParseMultipleWithOutcomes(filenames)
+}
+
+// ParseMultipleEpisodeMetadataExplained works exactly like ParseMultipleEpisodeMetadata,
+// but also returns restored templates, group frequencies and fallback info for each directory
+func ParseMultipleEpisodeMetadataExplained(filenames []string) ([]EpisodeMetadata, MultipleExplanation) {
+	return defaultParser.ParseMultipleExplained(filenames)
+}
+
+// ParseMultiple works like ParseMultipleEpisodeMetadata using options of the parser
+func (p *Parser) ParseMultiple(filenames []string) []EpisodeMetadata {
+	result, _ := p.parseMultipleEpisodeMetadata(filenames, nil)
+	return result
+}
+
+// ParseMultipleWithOutcomes works like ParseMultipleEpisodeMetadataWithOutcomes using options of the parser
+func (p *Parser) ParseMultipleWithOutcomes(filenames []string) ([]EpisodeMetadata, []DirOutcome, error) {
+	result, outcomes := p.parseMultipleEpisodeMetadata(filenames, nil)
+	if len(outcomes) == 0 {
+		return result, outcomes, nil
+	}
+	for _, outcome := range outcomes {
+		if outcome.Method == DirMethodTemplate || outcome.Method == DirMethodAirDate {
+			return result, outcomes, nil
+		}
+	}
+	return result, outcomes, ErrSingleParserOnly
+}
+
+// ParseMultipleExplained works like ParseMultipleEpisodeMetadataExplained using options of the parser
+func (p *Parser) ParseMultipleExplained(filenames []string) ([]EpisodeMetadata, MultipleExplanation) {
+	explanation := MultipleExplanation{}
+	result, _ := p.parseMultipleEpisodeMetadata(filenames, &explanation)
+	return result, explanation
+}
+
+func (p *Parser) parseMultipleEpisodeMetadata(filenames []string, explanation *MultipleExplanation) ([]EpisodeMetadata, []DirOutcome) {
 	if len(filenames) == 0 {
-		return []EpisodeMetadata{}
+		return []EpisodeMetadata{}, []DirOutcome{}
 	}
 	if len(filenames) == 1 {
-		return []EpisodeMetadata{ParseSingleEpisodeMetadata(filenames[0])}
+		outcomes := make([]DirOutcome, 0, 1)
+		if p.isVideo(filenames[0]) {
+			outcomes = append(outcomes, DirOutcome{
+				Dir:    filepath.Dir(filenames[0]),
+				Method: DirMethodSkipped,
+			})
+		}
+		if explanation == nil {
+			return []EpisodeMetadata{p.ParseSingle(filenames[0])}, outcomes
+		}
+		metadata, fileExplanation := p.ParseSingleExplained(filenames[0])
+		explanation.Dirs = append(explanation.Dirs, DirExplanation{
+			Dir:      filepath.Dir(filenames[0]),
+			Fallback: true,
+			Files:    []Explanation{fileExplanation},
+		})
+		return []EpisodeMetadata{metadata}, outcomes
 	}
 
 	// process files in each dir separately
 	fileEntries := make([]*fileEntry, 0, len(filenames))
 	dirFileMap := make(map[string][]*fileEntry)
+	dirInfoMap := make(map[string]dirInfo)
 	for _, name := range filenames {
+		base := filepath.Base(name)
+		base = strings.TrimSuffix(base, filepath.Ext(base))
+		// only some files of a batch may have version suffix, it must not break the template
+		version, _ := parseVersion(base)
+		cleanedFileName := name
+		if p.stripBrackets {
+			cleanedFileName = preCleanFileName(name)
+		}
+		_, cleanedFileName = parseVersion(normalizeCJK(cleanedFileName))
 		entry := &fileEntry{
-			cleanedFileName: preCleanFileName(name),
+			filename:        name,
+			base:            base,
+			cleanedFileName: cleanedFileName,
 			dir:             filepath.Dir(name),
-			isVideo:         isVideo(name),
+			isVideo:         p.isVideo(name),
+			kind:            detectContentKind(base),
+			version:         version,
+		}
+		info, ok := dirInfoMap[entry.dir]
+		if !ok {
+			info = p.parseDirHierarchy(name)
+			dirInfoMap[entry.dir] = info
+		}
+		entry.dirInfo = info
+		// e.g. "Show/Specials/Show - 01.mkv"
+		if entry.kind == KindRegular {
+			entry.kind = info.kind
 		}
 		fileEntries = append(fileEntries, entry)
 		if entry.isVideo {
@@ -222,31 +721,53 @@ func ParseMultipleEpisodeMetadata(filenames []string) []EpisodeMetadata {
 		}
 	}
 
-	for _, entries := range dirFileMap {
-		dirFilenames := make([]string, 0, len(filenames))
-		for _, f := range entries {
-			dirFilenames = append(dirFilenames, f.cleanedFileName)
+	dirs := getDirs(dirFileMap)
+	outcomes := make([]DirOutcome, 0, len(dirs))
+	for _, dir := range dirs {
+		var dirExplanation *DirExplanation
+		if explanation != nil {
+			dirExplanation = &DirExplanation{Dir: dir}
 		}
-		dirResult, err := parseMultipleEpisodeMetadataImpl(dirFilenames)
-		if err != nil {
-			dirResult = fallbackToSingleParser(dirFilenames)
+		outcomes = append(outcomes, p.parseDir(dir, dirFileMap[dir], dirExplanation))
+		if dirExplanation != nil {
+			explanation.Dirs = append(explanation.Dirs, *dirExplanation)
 		}
-		for i, r := range dirResult {
-			entries[i].result = r
+		// files are grouped by seasons, files of different titles must not be mixed
+		for _, entry := range dirFileMap[dir] {
+			if entry.result.Season == "" {
+				entry.result.Season = entry.result.Title
+				entry.seasonFromTitle = true
+			}
 		}
 	}
 
-	dirs := getDirs(dirFileMap)
-	if len(dirs) > 1 {
+	// specials and extras dirs are not seasons, they are handled later
+	regularDirs := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		if dirInfoMap[dir].kind == KindRegular {
+			regularDirs = append(regularDirs, dir)
+		}
+	}
+	if len(regularDirs) > 1 {
 		seasonSet := getSeasonSet(dirFileMap)
 		// multiple dirs AND single season, decide by dirname
 		if len(seasonSet) == 1 {
-			t, err := restoreTemplate(dirs)
-			if err == nil && t.varCount() == 1 {
-				seasonsMap := parseChangingDirs(dirs, t.toRegex())
-				for dir, entries := range dirFileMap {
-					for _, entry := range entries {
+			// explicit season markers are more reliable than the template, e.g. "S1" and "Season 2"
+			seasonsMap, ok := dirSeasons(regularDirs, dirInfoMap)
+			if !ok {
+				t, err := restoreTemplate(regularDirs)
+				if err == nil && t.varCount() == 1 {
+					if explanation != nil {
+						explanation.DirTemplate = t.String()
+					}
+					seasonsMap, ok = parseChangingDirs(regularDirs, t.toRegex()), true
+				}
+			}
+			if ok {
+				for _, dir := range regularDirs {
+					for _, entry := range dirFileMap[dir] {
 						entry.result.Season = seasonsMap[dir]
+						entry.seasonFromTitle = false
 					}
 				}
 			}
@@ -261,15 +782,52 @@ func ParseMultipleEpisodeMetadata(filenames []string) []EpisodeMetadata {
 			for _, entry := range entries {
 				// lcp < len
 				if lcp <= len(entry.result.Season) {
+					// common prefix is the title, e.g. "Durarara!! X2 Ketsu" is "X2 Ketsu" of "Durarara!!"
+					if entry.seasonFromTitle {
+						entry.result.Title = postCleanData(substringStartEnd(entry.result.Season, 0, lcp))
+						entry.seasonFromTitle = false
+					}
 					entry.result.Season = postCleanData(substringStart(entry.result.Season, lcp))
 				}
 			}
 		}
 	}
 
+	for _, entry := range fileEntries {
+		if !entry.isVideo {
+			continue
+		}
+		if entry.seasonFromTitle {
+			entry.result.Season = ""
+		}
+		// template doesn't capture part if it's the same for all files of the dir
+		if entry.result.Part == 0 {
+			entry.result.Part, _ = parsePart(delimiterRegex.ReplaceAllLiteralString(entry.base, " "))
+		}
+		// directories fill the gaps, e.g. "Show/Season 2/05.mkv"
+		applyDirInfo(&entry.result, entry.dirInfo)
+	}
+
+	calcAbsoluteEpisodes(fileEntries)
+
+	for i, dir := range dirs {
+		if outcomes[i].Template != nil {
+			outcomes[i].Template.learnConstants(dirFileMap[dir])
+		}
+	}
+
 	result := make([]EpisodeMetadata, 0, len(filenames))
 	for _, entry := range fileEntries {
+		if entry.isVideo {
+			entry.result.Kind = entry.kind
+			applySpecialSeason(&entry.result)
+			entry.result.Interstitial = isInterstitial(entry.base, entry.result.Episode)
+			entry.result.Version = entry.version
+			entry.result.AirDate, _, _ = parseAirDate(entry.base)
+			entry.result.Year = releaseYear(entry.base, entry.result)
+			entry.result.Release = parseReleaseInfo(entry.base)
+		}
 		result = append(result, entry.result)
 	}
-	return result
+	return result, outcomes
 }
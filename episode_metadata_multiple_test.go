@@ -1,7 +1,9 @@
 package roflmeta
 
 import (
+	"errors"
 	"fmt"
+	"github.com/go-playground/assert/v2"
 	"testing"
 )
 
@@ -54,6 +56,7 @@ func TestMultipleEpisodeMetadata1(t *testing.T) {
 
 	metadataArr := ParseMultipleEpisodeMetadata(input)
 	assertDiff(t, metadataArr, expected)
+	assert.Equal(t, metadataArr[0].Year, 2011)
 }
 
 func TestMultipleEpisodeMetadata2(t *testing.T) {
@@ -89,7 +92,7 @@ func TestMultipleEpisodeMetadata3(t *testing.T) {
 	expected = append(expected, genOutput("X2 Ketsu", "%02d", 1, 12)...)
 	expected = append(expected, genSingle("X2 Ketsu", "7.5"))
 	expected = append(expected, genOutput("X2 Shou", "%02d", 1, 12)...)
-	expected = append(expected, genSingle("X2 Shou", "OVA"))
+	expected = append(expected, genSingle("0", "OVA"))
 	expected = append(expected, genOutput("X2 Ten", "%02d", 1, 9)...)
 	expected = append(expected, genSingle("X2 Ten", "1.5"))
 	expected = append(expected, genOutput("X2 Ten", "%02d", 10, 12)...)
@@ -104,8 +107,8 @@ func TestMultipleEpisodeMetadata4(t *testing.T) {
 	input = append(input, "[Judas] Jujutsu Kaisen - S01SP1.mkv")
 
 	expected := make([]EpisodeMetadata, 0, 256)
-	expected = append(expected, genOutput("01", "E%02d", 1, 24)...)
-	expected = append(expected, genSingle("01", "SP1"))
+	expected = append(expected, genOutput("01", "%02d", 1, 24)...)
+	expected = append(expected, genSingle("0", "SP1"))
 
 	metadataArr := ParseMultipleEpisodeMetadata(input)
 	assertDiff(t, metadataArr, expected)
@@ -175,7 +178,7 @@ func TestMultipleEpisodeMetadata8(t *testing.T) {
 	expected = append(expected, genOutput("X2 Ketsu", "%02d", 1, 12)...)
 	expected = append(expected, genSingle("X2 Ketsu", "7.5"))
 	expected = append(expected, genOutput("X2 Shou", "%02d", 1, 12)...)
-	expected = append(expected, genSingle("X2 Shou", "OVA"))
+	expected = append(expected, genSingle("0", "OVA"))
 	expected = append(expected, genOutput("X2 Ten", "%02d", 1, 9)...)
 	expected = append(expected, genSingle("X2 Ten", "1.5"))
 	expected = append(expected, genOutput("X2 Ten", "%02d", 10, 12)...)
@@ -206,10 +209,10 @@ func TestMultipleEpisodeMetadata10(t *testing.T) {
 	input = append(input, "[Judas] Jujutsu Kaisen - S01SP1.txt")
 
 	expected := make([]EpisodeMetadata, 0, 256)
-	expected = append(expected, genOutput("01", "E%02d", 1, 12)...)
+	expected = append(expected, genOutput("01", "%02d", 1, 12)...)
 	expected = append(expected, genSingle("", ""))
-	expected = append(expected, genOutput("01", "E%02d", 13, 24)...)
-	expected = append(expected, genSingle("01", "SP1"))
+	expected = append(expected, genOutput("01", "%02d", 13, 24)...)
+	expected = append(expected, genSingle("0", "SP1"))
 	expected = append(expected, genSingle("", ""))
 
 	metadataArr := ParseMultipleEpisodeMetadata(input)
@@ -231,10 +234,13 @@ func TestMultipleEpisodeMetadata11(t *testing.T) {
 	input = append(input, "[SubsPlease] Heion Sedai no Idaten-tachi - 11 (1080p) [D298BC5A].mkv")
 
 	expected := make([]EpisodeMetadata, 0, 12)
-	expected = append(expected, genOutput("Heion Sedai no Idaten tachi", "%02d", 1, 11)...)
+	expected = append(expected, genOutput("", "%02d", 1, 11)...)
 
 	metadataArr := ParseMultipleEpisodeMetadata(input)
 	assertDiff(t, metadataArr, expected)
+	for _, metadata := range metadataArr {
+		assert.Equal(t, metadata.Title, "Heion Sedai no Idaten tachi")
+	}
 }
 
 func TestMultipleEpisodeMetadata12(t *testing.T) {
@@ -247,8 +253,8 @@ func TestMultipleEpisodeMetadata12(t *testing.T) {
 	expected := make([]EpisodeMetadata, 0, 256)
 	expected = append(expected, genSingle("", "ED"))
 	expected = append(expected, genSingle("", "OP"))
-	expected = append(expected, genOutput("", "Special E%d", 1, 3)...)
-	expected = append(expected, genOutput("", "E%02d", 1, 11)...)
+	expected = append(expected, genOutput("0", "Special E%d", 1, 3)...)
+	expected = append(expected, genOutput("", "%02d", 1, 11)...)
 
 	metadataArr := ParseMultipleEpisodeMetadata(input)
 	assertDiff(t, metadataArr, expected)
@@ -290,16 +296,23 @@ func TestMultipleEpisodeMetadata13(t *testing.T) {
 	input = append(input, "[Commie] Sayonara Zetsubou Sensei (2012) - BD Special [BD 720p AAC] [BEA51F1F].mkv")
 
 	expected := make([]EpisodeMetadata, 0, 256)
-	expected = append(expected, genOutput("Sayonara Zetsubou Sensei", "%02d", 1, 12)...)
-	expected = append(expected, genSingle("NCED", "99"))
-	expected = append(expected, genOutput("Zoku Sayonara Zetsubou Sensei", "%02d", 1, 13)...)
-	expected = append(expected, genOutput("Goku Sayonara Zetsubou Sensei", "%02d", 1, 3)...)
-	expected = append(expected, genOutput("Zan Sayonara Zetsubou Sensei", "%02d", 1, 13)...)
-	expected = append(expected, genOutput("Zan Sayonara Zetsubou Sensei Bangaichi", "%02d", 1, 2)...)
-	expected = append(expected, genSingle("Sayonara Zetsubou Sensei", "BD Special"))
+	expected = append(expected, genOutput("", "%02d", 1, 12)...)
+	expected = append(expected, genSingle("", "99"))
+	expected = append(expected, genOutput("", "%02d", 1, 13)...)
+	expected = append(expected, genOutput("", "%02d", 1, 3)...)
+	expected = append(expected, genOutput("", "%02d", 1, 13)...)
+	expected = append(expected, genOutput("", "%02d", 1, 2)...)
+	expected = append(expected, genSingle("0", "BD Special"))
 
 	metadataArr := ParseMultipleEpisodeMetadata(input)
 	assertDiff(t, metadataArr, expected)
+	assert.Equal(t, metadataArr[0].Title, "Sayonara Zetsubou Sensei")
+	assert.Equal(t, metadataArr[12].Title, "NCED")
+	assert.Equal(t, metadataArr[13].Title, "Zoku Sayonara Zetsubou Sensei")
+	assert.Equal(t, metadataArr[26].Title, "Goku Sayonara Zetsubou Sensei")
+	assert.Equal(t, metadataArr[29].Title, "Zan Sayonara Zetsubou Sensei")
+	assert.Equal(t, metadataArr[42].Title, "Zan Sayonara Zetsubou Sensei Bangaichi")
+	assert.Equal(t, metadataArr[44].Title, "Sayonara Zetsubou Sensei")
 }
 
 func TestMultipleEpisodeMetadata14(t *testing.T) {
@@ -310,9 +323,412 @@ func TestMultipleEpisodeMetadata14(t *testing.T) {
 
 	expected := make([]EpisodeMetadata, 0, 256)
 	expected = append(expected, genOutput("01", "%03d", 1, 148)...)
-	expected = append(expected, genSingle("Hunter X Hunter", "1"))
-	expected = append(expected, genSingle("Hunter X Hunter", "2"))
+	expected = append(expected, genSingle("", "1"))
+	expected = append(expected, genSingle("", "2"))
+
+	metadataArr := ParseMultipleEpisodeMetadata(input)
+	assertDiff(t, metadataArr, expected)
+	assert.Equal(t, metadataArr[0].Title, "Hunter x Hunter")
+	assert.Equal(t, metadataArr[148].Title, "Hunter X Hunter")
+}
+
+func TestMultipleEpisodeMetadataConfidence(t *testing.T) {
+	input := genInput("[Judas] Jujutsu Kaisen - S01E%02d.mkv", 1, 24)
+	input = append(input, "readme.txt")
+
+	metadataArr := ParseMultipleEpisodeMetadata(input)
+	for i := 0; i < 24; i++ {
+		if metadataArr[i].Confidence != confidenceTemplateEpisodes {
+			t.Fatalf("Invalid confidence at #%d, expected %f, got %f", i, confidenceTemplateEpisodes, metadataArr[i].Confidence)
+		}
+	}
+	if metadataArr[24].Confidence != 0 {
+		t.Fatalf("Expected zero confidence for non-video file, got %f", metadataArr[24].Confidence)
+	}
+}
+
+func TestMultipleEpisodeMetadataConfidenceWeak(t *testing.T) {
+	strong := ParseMultipleEpisodeMetadata(genInput("Dir/Show - %02d.mkv", 1, 12))
+	// the template "Dir/* 0*.mkv" describes little of the filenames
+	weak := ParseMultipleEpisodeMetadata([]string{"Dir/Alpha Centauri 01.mkv", "Dir/Betelgeuse Rising 02.mkv", "Dir/Gamma Ray Burst 03.mkv", "Dir/Deneb Is Far 04.mkv"})
+	if weak[0].Confidence >= strong[0].Confidence {
+		t.Fatalf("Expected %f to be less than %f", weak[0].Confidence, strong[0].Confidence)
+	}
+	few := ParseMultipleEpisodeMetadata(genInput("Dir/Show - %02d.mkv", 1, 2))
+	if few[0].Confidence >= strong[0].Confidence {
+		t.Fatalf("Expected %f to be less than %f", few[0].Confidence, strong[0].Confidence)
+	}
+}
+
+func TestMultipleEpisodeMetadataExplained(t *testing.T) {
+	input := make([]string, 0, 256)
+	input = append(input, genInput("season 01/episode %02d.mkv", 1, 24)...)
+	input = append(input, genInput("season 02/episode %02d.mkv", 1, 24)...)
+	input = append(input, "extras/trailer.mkv")
+
+	metadataArr, explanation := ParseMultipleEpisodeMetadataExplained(input)
+	assertDiff(t, metadataArr, ParseMultipleEpisodeMetadata(input))
+	if len(explanation.Dirs) != 3 {
+		t.Fatalf("Invalid dir count, expected 3, got %d", len(explanation.Dirs))
+	}
+	extras := explanation.Dirs[0]
+	if !extras.Fallback || len(extras.Files) != 1 {
+		t.Fatalf("Expected single parser fallback for '%s'", extras.Dir)
+	}
+	season := explanation.Dirs[1]
+	if season.Dir != "season 01" || season.Template != "season 01/episode *.mkv" || season.Fallback {
+		t.Fatalf("Invalid explanation for '%s': template '%s'", season.Dir, season.Template)
+	}
+	if explanation.DirTemplate != "" {
+		t.Fatalf("Expected unused dir template, got '%s'", explanation.DirTemplate)
+	}
+}
+
+func TestMultipleEpisodeMetadataWithOutcomes1(t *testing.T) {
+	input := make([]string, 0, 256)
+	input = append(input, genInput("[Judas] Hunter x Hunter (2011) - Episodes 001-148/[Judas] Hunter x Hunter (2011) - S01E%03d.mkv", 1, 148)...)
+	input = append(input, "[Judas] Hunter x Hunter (2011) - Movies/[Judas] Hunter X Hunter - Movie 1 - Phantom Rouge.mkv")
+
+	_, outcomes, err := ParseMultipleEpisodeMetadataWithOutcomes(input)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(outcomes) != 2 {
+		t.Fatalf("Invalid outcome count, expected 2, got %d", len(outcomes))
+	}
+	if outcomes[0].Method != DirMethodTemplate || outcomes[0].Err != nil {
+		t.Fatalf("Expected template method for '%s', got %s", outcomes[0].Dir, outcomes[0].Method)
+	}
+	if outcomes[1].Method != DirMethodSkipped {
+		t.Fatalf("Expected skipped method for '%s', got %s", outcomes[1].Dir, outcomes[1].Method)
+	}
+}
+
+func TestMultipleEpisodeMetadataWithOutcomes2(t *testing.T) {
+	input := []string{"A 01 x 1.mkv", "B 02 y 1.mkv", "C 03 y 2.mkv", "D 03 z 3.mkv"}
+
+	metadataArr, outcomes, err := ParseMultipleEpisodeMetadataWithOutcomes(input)
+	assertDiff(t, metadataArr, ParseMultipleEpisodeMetadata(input))
+	if !errors.Is(err, ErrSingleParserOnly) {
+		t.Fatalf("Expected ErrSingleParserOnly, got %v", err)
+	}
+	if len(outcomes) != 1 || outcomes[0].Method != DirMethodFallback || outcomes[0].Err != ErrMultipleFailed {
+		t.Fatalf("Expected single fallback outcome, got %v", outcomes)
+	}
+}
+
+func TestMultipleEpisodeMetadataRange(t *testing.T) {
+	input := make([]string, 0, 16)
+	input = append(input, genInput("Show - %02d.mkv", 1, 4)...)
+	input = append(input, "Show - 05-06.mkv")
+	input = append(input, genInput("Show - %02d.mkv", 7, 12)...)
+
+	metadataArr := ParseMultipleEpisodeMetadata(input)
+	if metadataArr[4].Episode != "05" || metadataArr[4].EpisodeEnd != "06" {
+		t.Fatalf("Invalid range, expected 05-06, got %s-%s", metadataArr[4].Episode, metadataArr[4].EpisodeEnd)
+	}
+	for i, metadata := range metadataArr {
+		if i != 4 && metadata.EpisodeEnd != "" {
+			t.Fatalf("Unexpected range end at #%d: %s", i, metadata.EpisodeEnd)
+		}
+	}
+}
+
+func TestMultipleEpisodeMetadataKind1(t *testing.T) {
+	input := make([]string, 0, 256)
+	input = append(input, "[Reaktor] The Tatami Galaxy ED [1080p][x265][10-bit].mkv")
+	input = append(input, "[Reaktor] The Tatami Galaxy OP [1080p][x265][10-bit].mkv")
+	input = append(input, genInput("[Reaktor] The Tatami Galaxy - Special E%d [1080p][x265][10-bit].mkv", 1, 3)...)
+	input = append(input, genInput("[Reaktor] The Tatami Galaxy - E%02d [1080p][x265][10-bit].mkv", 1, 11)...)
 
 	metadataArr := ParseMultipleEpisodeMetadata(input)
+	expected := []ContentKind{KindEnding, KindOpening, KindSpecial, KindSpecial, KindSpecial}
+	for i, metadata := range metadataArr {
+		kind := KindRegular
+		if i < len(expected) {
+			kind = expected[i]
+		}
+		if metadata.Kind != kind {
+			t.Fatalf("Invalid kind at #%d, expected %s, got %s", i, kind, metadata.Kind)
+		}
+	}
+}
+
+func TestMultipleEpisodeMetadataKind2(t *testing.T) {
+	input := make([]string, 0, 256)
+	input = append(input, genInput("[Judas] Jujutsu Kaisen - S01E%02d.mkv", 1, 12)...)
+	input = append(input, "[Judas] Jujutsu Kaisen NCOP1 (Creditless).mkv")
+	input = append(input, "[Judas] Menu.mkv")
+
+	expected := make([]EpisodeMetadata, 0, 256)
+	expected = append(expected, genOutput("01", "%02d", 1, 12)...)
+	expected = append(expected, genSingle("", "Jujutsu Kaisen NCOP1"))
+	expected = append(expected, genSingle("", "Menu"))
+
+	metadataArr, outcomes, err := ParseMultipleEpisodeMetadataWithOutcomes(input)
 	assertDiff(t, metadataArr, expected)
+	if err != nil || outcomes[0].Method != DirMethodTemplate {
+		t.Fatalf("Expected template method, got %s", outcomes[0].Method)
+	}
+	if metadataArr[12].Kind != KindOpening || metadataArr[13].Kind != KindExtra {
+		t.Fatalf("Invalid kinds: %s, %s", metadataArr[12].Kind, metadataArr[13].Kind)
+	}
+}
+
+func TestMultipleEpisodeMetadataInterstitial(t *testing.T) {
+	input := make([]string, 0, 256)
+	input = append(input, genInput("[Anime Time] Durarara!!/[Anime Time] Durarara!! - %02d.mkv", 1, 12)...)
+	input = append(input, "[Anime Time] Durarara!!/[Anime Time] Durarara!! - 12.5.mkv")
+	input = append(input, genInput("[Anime Time] Durarara!!/[Anime Time] Durarara!! - %02d.mkv", 13, 25)...)
+
+	metadataArr := ParseMultipleEpisodeMetadata(input)
+	for i, metadata := range metadataArr {
+		if metadata.Interstitial != (i == 12) {
+			t.Fatalf("Invalid interstitial flag at #%d: %s", i, metadata.Episode)
+		}
+	}
+	if metadataArr[12].AbsoluteEpisode != 0 {
+		t.Fatalf("Expected no absolute episode for 12.5, got %d", metadataArr[12].AbsoluteEpisode)
+	}
+}
+
+func TestMultipleEpisodeMetadataRelease(t *testing.T) {
+	input := make([]string, 0, 256)
+	input = append(input, genInput("[DB]Bakemonogatari_-_%02d_(10bit_BD1080p_x265).mkv", 1, 14)...)
+	input = append(input, "[DB]Bakemonogatari_-_NCED01_(10bit_BD1080p_x265).mkv")
+
+	metadataArr := ParseMultipleEpisodeMetadata(input)
+	for _, metadata := range metadataArr {
+		assert.Equal(t, metadata.Release, ReleaseInfo{
+			Group:      "DB",
+			Resolution: "1080p",
+			VideoCodec: "H.265",
+			Source:     "BluRay",
+			TenBit:     true,
+		})
+	}
+}
+
+func TestMultipleEpisodeMetadataVersion(t *testing.T) {
+	input := make([]string, 0, 256)
+	input = append(input, genInput("[SubsPlease] Heion Sedai no Idaten-tachi - %02d (1080p).mkv", 1, 4)...)
+	input = append(input, "[SubsPlease] Heion Sedai no Idaten-tachi - 05v2 (1080p).mkv")
+	input = append(input, genInput("[SubsPlease] Heion Sedai no Idaten-tachi - %02d (1080p).mkv", 6, 11)...)
+
+	expected := make([]EpisodeMetadata, 0, 256)
+	expected = append(expected, genOutput("", "%02d", 1, 11)...)
+
+	metadataArr, outcomes, err := ParseMultipleEpisodeMetadataWithOutcomes(input)
+	assertDiff(t, metadataArr, expected)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, outcomes[0].Method, DirMethodTemplate)
+	for i, metadata := range metadataArr {
+		if i == 4 {
+			assert.Equal(t, metadata.Version, 2)
+		} else {
+			assert.Equal(t, metadata.Version, 0)
+		}
+	}
+}
+
+func TestMultipleEpisodeMetadataAirDate(t *testing.T) {
+	input := make([]string, 0, 256)
+	input = append(input, genInput("Show.2023.05.%02d.Guest.Name.1080p.mkv", 1, 9)...)
+	input = append(input, genInput("Show.2023.05.%02d.Other.Guest.1080p.mkv", 10, 20)...)
+
+	expected := make([]EpisodeMetadata, 0, 256)
+	expected = append(expected, genOutput("", "2023-05-%02d", 1, 20)...)
+
+	metadataArr, outcomes, err := ParseMultipleEpisodeMetadataWithOutcomes(input)
+	assertDiff(t, metadataArr, expected)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, outcomes[0].Method, DirMethodAirDate)
+	assert.Equal(t, metadataArr[0].Title, "Show")
+	assert.Equal(t, metadataArr[0].AirDate.Day(), 1)
+}
+
+func TestMultipleEpisodeMetadataAirDateOutlier(t *testing.T) {
+	input := genInput("Show/Show.2023.05.%02d.Guest.mkv", 10, 20)
+	input = append(input, "Show/Show.Best.Of.mkv")
+
+	metadataArr, outcomes, err := ParseMultipleEpisodeMetadataWithOutcomes(input)
+	assertDiff(t, metadataArr[:11], genOutput("", "2023-05-%02d", 10, 20))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, outcomes[0].Method, DirMethodAirDate)
+	assert.Equal(t, outcomes[0].Outliers, []string{"Show/Show.Best.Of.mkv"})
+}
+
+func TestMultipleEpisodeMetadataAirDateSameDay(t *testing.T) {
+	input := genInput("Show.2023.05.%02d.Guest.mkv", 10, 20)
+	input = append(input, "Show.2023.05.20.Guest.Part2.mkv")
+
+	expected := genOutput("", "2023-05-%02d", 10, 20)
+	expected = append(expected, genSingle("", "2023-05-20"))
+
+	metadataArr, outcomes, err := ParseMultipleEpisodeMetadataWithOutcomes(input)
+	assertDiff(t, metadataArr, expected)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, outcomes[0].Method, DirMethodAirDate)
+}
+
+func TestMultipleEpisodeMetadataCJK(t *testing.T) {
+	numerals := []string{"一", "二", "三", "四", "五", "六", "七", "八", "九", "十", "十一", "十二"}
+	input := make([]string, 0, 256)
+	for _, numeral := range numerals {
+		input = append(input, "作品名/作品名 第"+numeral+"話.mkv")
+	}
+
+	expected := make([]EpisodeMetadata, 0, 256)
+	expected = append(expected, genOutput("", "%d", 1, 12)...)
+
+	metadataArr := ParseMultipleEpisodeMetadata(input)
+	assertDiff(t, metadataArr, expected)
+}
+
+func TestMultipleEpisodeMetadataPart(t *testing.T) {
+	input := make([]string, 0, 256)
+	input = append(input, genInput("[SubsPlease] Shingeki no Kyojin The Final Season Part 1 - %02d (1080p).mkv", 1, 16)...)
+	input = append(input, genInput("[SubsPlease] Shingeki no Kyojin The Final Season Part 2 - %02d (1080p).mkv", 1, 12)...)
+
+	expected := make([]EpisodeMetadata, 0, 256)
+	expected = append(expected, genOutput("Final", "%02d", 1, 16)...)
+	expected = append(expected, genOutput("Final", "%02d", 1, 12)...)
+
+	metadataArr := ParseMultipleEpisodeMetadata(input)
+	assertDiff(t, metadataArr, expected)
+	assert.Equal(t, metadataArr[0].Part, 1)
+	assert.Equal(t, metadataArr[16].Part, 2)
+	assert.Equal(t, metadataArr[16].Title, "Shingeki no Kyojin")
+}
+
+func TestMultipleEpisodeMetadataDirs(t *testing.T) {
+	input := make([]string, 0, 256)
+	input = append(input, genInput("Show/S1/Show - %02d.mkv", 1, 12)...)
+	input = append(input, genInput("Show/Season 2/Show - %02d.mkv", 1, 10)...)
+	input = append(input, genInput("Show/Specials/Show - %02d.mkv", 1, 12)...)
+	input = append(input, "Show/Extras/Show - Making of.mkv")
+
+	expected := make([]EpisodeMetadata, 0, 256)
+	expected = append(expected, genOutput("1", "%02d", 1, 12)...)
+	expected = append(expected, genOutput("2", "%02d", 1, 10)...)
+	expected = append(expected, genOutput("0", "%02d", 1, 12)...)
+	expected = append(expected, genSingle("", "Making of"))
+
+	metadataArr := ParseMultipleEpisodeMetadata(input)
+	assertDiff(t, metadataArr, expected)
+	assert.Equal(t, metadataArr[0].Title, "Show")
+	assert.Equal(t, metadataArr[22].Kind, KindSpecial)
+	assert.Equal(t, metadataArr[34].Kind, KindExtra)
+	assert.Equal(t, metadataArr[12].AbsoluteEpisode, 13)
+}
+
+func TestMultipleEpisodeMetadataSeasonLikeTitle(t *testing.T) {
+	input := make([]string, 0, 16)
+	input = append(input, genInput("2/2 S2E%02d.mkv", 1, 6)...)
+	input = append(input, genInput("2/2 S3E%02d.mkv", 1, 6)...)
+
+	metadataArr := ParseMultipleEpisodeMetadata(input)
+	assert.Equal(t, metadataArr[0].Title, "2")
+	assert.Equal(t, metadataArr[0].Season, "2")
+	assert.Equal(t, metadataArr[6].Season, "3")
+}
+
+func TestMultipleEpisodeMetadataRegularOnly(t *testing.T) {
+	input := make([]string, 0, 16)
+	input = append(input, genInput("[Judas] Jujutsu Kaisen - S01E%02d.mkv", 1, 12)...)
+	input = append(input, "[Judas] Jujutsu Kaisen - S01SP1.mkv")
+
+	metadataArr, outcomes, _ := ParseMultipleEpisodeMetadataWithOutcomes(input)
+	// the full template is " Jujutsu Kaisen - S01*.mkv", its episodes would be "E01", ...
+	assert.Equal(t, outcomes[0].Template.Template, "Jujutsu Kaisen - S01E*.mkv")
+	assert.Equal(t, metadataArr[0].Episode, "01")
+	assert.Equal(t, metadataArr[12].Season, "0")
+	assert.Equal(t, metadataArr[12].Episode, "SP1")
+	assert.Equal(t, metadataArr[12].Kind, KindSpecial)
+}
+
+func TestMultipleEpisodeMetadataOutliers(t *testing.T) {
+	input := make([]string, 0, 16)
+	input = append(input, genInput("Frieren/[Group] Sousou no Frieren - %02d (1080p).mkv", 1, 6)...)
+	input = append(input, "Frieren/Sousou no Frieren Ep 7 FINAL.mkv")
+	input = append(input, "Frieren/video_2023_10_01.mkv")
+	input = append(input, genInput("Frieren/[Group] Sousou no Frieren - %02d (1080p).mkv", 8, 12)...)
+
+	expected := make([]EpisodeMetadata, 0, 16)
+	expected = append(expected, genOutput("", "%02d", 1, 6)...)
+	expected = append(expected, genSingle("", "7"))
+	expected = append(expected, genSingle("", "2023-10-01"))
+	expected = append(expected, genOutput("", "%02d", 8, 12)...)
+
+	metadataArr, outcomes, err := ParseMultipleEpisodeMetadataWithOutcomes(input)
+	assertDiff(t, metadataArr, expected)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, outcomes[0].Method, DirMethodTemplate)
+	assert.Equal(t, outcomes[0].Outliers, []string{"Frieren/Sousou no Frieren Ep 7 FINAL.mkv", "Frieren/video_2023_10_01.mkv"})
+	assert.Equal(t, metadataArr[0].Title, "Sousou no Frieren")
+	assert.Equal(t, metadataArr[6].Title, "Sousou no Frieren")
+	assert.Equal(t, metadataArr[12].AbsoluteEpisode, 12)
+}
+
+func TestMultipleEpisodeMetadataOutliersSmall(t *testing.T) {
+	metadataArr, outcomes, err := ParseMultipleEpisodeMetadataWithOutcomes([]string{"Show/Show - 01.mkv", "Show/Show - 02.mkv", "Show/video_2023_10_01.mkv"})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, outcomes[0].Outliers, []string{"Show/video_2023_10_01.mkv"})
+	assert.Equal(t, metadataArr[1].Episode, "2")
+	assert.Equal(t, metadataArr[2].Episode, "2023-10-01")
+	// samples are extras, they are never parsed with the template of episodes
+	metadataArr, outcomes, _ = ParseMultipleEpisodeMetadataWithOutcomes([]string{"Show/Show - 01.mkv", "Show/Show - 02.mkv", "Show/Sample.mkv"})
+	assert.Equal(t, outcomes[0].Outliers, []string{"Show/Sample.mkv"})
+	assert.Equal(t, metadataArr[1].Episode, "2")
+	assert.Equal(t, metadataArr[2].Episode, "Sample")
+	assert.Equal(t, metadataArr[2].Kind, KindExtra)
+	// the template "Show/S*.mkv" splits words
+	metadataArr, outcomes, _ = ParseMultipleEpisodeMetadataWithOutcomes([]string{"Show/Show - 01.mkv", "Show/Sample.mkv"})
+	assert.Equal(t, outcomes[0].Method, DirMethodFallback)
+	assert.Equal(t, metadataArr[0].Title, "Show")
+	assert.Equal(t, metadataArr[0].Episode, "01")
+	assert.Equal(t, metadataArr[1].Episode, "Sample")
+}
+
+func TestMultipleEpisodeMetadataOutliersTypo(t *testing.T) {
+	input := genInput("Show/Show.S01E%02d.1080p.WEB-DL.x264-NTb.mkv", 1, 8)
+	input[4] = "Show/Show.S01E05.1080p.WEB-DL.x264-NTB.mkv"
+
+	metadataArr, outcomes, err := ParseMultipleEpisodeMetadataWithOutcomes(input)
+	assertDiff(t, metadataArr[:4], genOutput("01", "%d", 1, 4))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, outcomes[0].Outliers, []string{"Show/Show.S01E05.1080p.WEB-DL.x264-NTB.mkv"})
+	assert.Equal(t, metadataArr[4].Episode, "05")
+	assert.Equal(t, metadataArr[5].Episode, "6")
+	assert.Equal(t, metadataArr[5].Season, "01")
+}
+
+func TestMultipleEpisodeMetadataOutliersExtraText(t *testing.T) {
+	input := genInput("Show/Show - %02d.mkv", 1, 8)
+	input[3] = "Show/Show - 04 FINAL CUT director.mkv"
+
+	metadataArr, outcomes, err := ParseMultipleEpisodeMetadataWithOutcomes(input)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, outcomes[0].Method, DirMethodTemplate)
+	assert.Equal(t, outcomes[0].Outliers, []string{"Show/Show - 04 FINAL CUT director.mkv"})
+	assert.Equal(t, metadataArr[3].Episode, "04")
+	assert.Equal(t, metadataArr[4].Episode, "5")
+}
+
+func TestValueShape(t *testing.T) {
+	assert.Equal(t, valueShape("12"), "0")
+	assert.Equal(t, valueShape("12.5"), "0")
+	assert.Equal(t, valueShape("b"), "a")
+	assert.Equal(t, valueShape("B"), "A")
+	assert.Equal(t, valueShape("4 FINAL CUT director"), "0 A A a")
+	assert.Equal(t, valueShape("Final Cut"), "Aa Aa")
+	assert.Equal(t, valueShape("第十二"), "L")
+}
+
+func TestMultipleEpisodeMetadataOutliersExplained(t *testing.T) {
+	input := genInput("Frieren/[Group] Sousou no Frieren - %02d (1080p).mkv", 1, 6)
+	input = append(input, "Frieren/Sousou no Frieren Ep 7 FINAL.mkv")
+	_, explanation := ParseMultipleEpisodeMetadataExplained(input)
+	// failed attempts are not recorded
+	assert.Equal(t, explanation.Dirs[0].Template, "Frieren/ Sousou no Frieren - 0* .mkv")
 }
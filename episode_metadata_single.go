@@ -8,18 +8,17 @@ import (
 )
 
 var clusterRegex = regexp.MustCompile("\\s{2,}")
-var numberWithSpaceRegex = regexp.MustCompile(" (\\d+(?:.\\d+)?)")
-var startsWithNumberRegex = regexp.MustCompile("^(\\d+(?:.\\d+)?).*$")
+var numberWithSpaceRegex = regexp.MustCompile(" (\\d+(?:\\.\\d+)?)")
+var startsWithNumberRegex = regexp.MustCompile("^(\\d+(?:\\.\\d+)?).*$")
 var fullNumberRegex = regexp.MustCompile("^\\d+$")
 var delimiterRegex = regexp.MustCompile("[-_]")
 
-//var badWords = []string{"x264", "x265", "opus", "aac", "mp4", "mp3", "mkv", "hevc", "avc", "flac", "dual", "webdl", "dvd", "cd", "rip"}
-//var badRegexes = []*regexp.Regexp{regexp.MustCompile("\\d+\\s*p"), regexp.MustCompile("\\dk")}
-
 var sSeRegex = regexp.MustCompile("(?i)s\\s*(\\d+)\\s*e\\s*(\\d+)")
 var sEsRegex = regexp.MustCompile("(?i)e\\s*(\\d+)\\s*s\\s*(\\d+)")
-var sEpisodeRegex = regexp.MustCompile("(?i)episode\\s*(\\d+)")
-var sEpRegex = regexp.MustCompile("(?i)ep\\s*(\\d+)")
+
+// decimal episodes like "Episode 12.5" have short fractions, so "Ep05.1080p" is still episode 05
+var sEpisodeRegex = regexp.MustCompile("(?i)episode\\s*(\\d+(?:\\.\\d{1,2})?)(\\D|$)")
+var sEpRegex = regexp.MustCompile("(?i)ep\\s*(\\d+(?:\\.\\d{1,2})?)(\\D|$)")
 var sSeasonRegex = regexp.MustCompile("(?i)season\\s*(\\d+)")
 var sSxERegex = regexp.MustCompile("(?i)(\\d+)\\s*x\\s*(\\d+)")
 var eDotSpaceRegex = regexp.MustCompile("(?i)(\\d+)\\.\\s")
@@ -28,59 +27,337 @@ var eDotSpaceRegex = regexp.MustCompile("(?i)(\\d+)\\.\\s")
 // See EpisodeMetadata for details
 // For a list of filenames use ParseMultipleEpisodeMetadata
 func ParseSingleEpisodeMetadata(filename string) EpisodeMetadata {
-	if !isVideo(filename) {
+	return defaultParser.ParseSingle(filename)
+}
+
+// ParseSingleEpisodeMetadataExplained works exactly like ParseSingleEpisodeMetadata,
+// but also returns the ordered list of rules that were tried and the one that won
+func ParseSingleEpisodeMetadataExplained(filename string) (EpisodeMetadata, Explanation) {
+	return defaultParser.ParseSingleExplained(filename)
+}
+
+// ParseSingle works like ParseSingleEpisodeMetadata using options of the parser
+func (p *Parser) ParseSingle(filename string) EpisodeMetadata {
+	return p.parseSingleWithDirs(filename, nil)
+}
+
+// ParseSingleExplained works like ParseSingleEpisodeMetadataExplained using options of the parser
+func (p *Parser) ParseSingleExplained(filename string) (EpisodeMetadata, Explanation) {
+	explanation := Explanation{Filename: filename}
+	result := p.parseSingleWithDirs(filename, &explanation)
+	return result, explanation
+}
+
+// parseSingleWithDirs also uses directories of the path to fill the gaps, e.g. "Show/Season 2/05.mkv"
+// The multiple parser handles directories on its own
+func (p *Parser) parseSingleWithDirs(filename string, explanation *Explanation) EpisodeMetadata {
+	result := p.parseSingle(filename, explanation)
+	if p.isVideo(filename) {
+		applyDirInfo(&result, p.parseDirHierarchy(filename))
+		applySpecialSeason(&result)
+	}
+	return result
+}
+
+func (p *Parser) parseSingle(filename string, explanation *Explanation) EpisodeMetadata {
+	if !p.isVideo(filename) {
 		return EpisodeMetadata{}
 	}
-	var resultSeason string
-	var resultEpisode string
 
-	// remove path and extension, make lowercase
+	// remove path and extension
 	base := filepath.Base(filename)
 	base = strings.TrimSuffix(base, filepath.Ext(base))
 
+	// version suffix must not leak into episode, e.g. "05v2"
+	version, unversioned := parseVersion(normalizeCJK(base))
+	result := p.parseSeasonAndEpisode(unversioned, explanation)
+	result.Kind = detectContentKind(base)
+	applySpecialSeason(&result)
+	result.Interstitial = isInterstitial(unversioned, result.Episode)
+	result.Version = version
+	result.Part, _ = parsePart(delimiterRegex.ReplaceAllLiteralString(unversioned, " "))
+	result.AirDate, _, _ = parseAirDate(unversioned)
+	result.Year = releaseYear(unversioned, result)
+	result.Release = parseReleaseInfo(base)
+	return result
+}
+
+// parseSeasonAndEpisode applies rules to the filename without path and extension
+func (p *Parser) parseSeasonAndEpisode(base string, explanation *Explanation) EpisodeMetadata {
 	// replace delimiters with spaces
-	spaced := delimiterRegex.ReplaceAllLiteralString(base, " ")
-
-	// try to find popular formats
-	if test := sSxERegex.FindStringSubmatch(spaced); test != nil {
-		season, _ := strconv.Atoi(test[1])
-		if season < 100 {
-			return EpisodeMetadata{
-				Season:  test[1],
-				Episode: test[2],
+	state := RuleState{
+		Base:    base,
+		Working: delimiterRegex.ReplaceAllLiteralString(base, " "),
+		parser:  p,
+	}
+	for _, rule := range p.rules {
+		if builtin, ok := rule.(builtinRule); ok {
+			if builtin.apply(&state, explanation) {
+				return state.Result
 			}
+			continue
 		}
-	}
-	if test := sEsRegex.FindStringSubmatch(spaced); test != nil {
-		return EpisodeMetadata{
-			Season:  test[2],
-			Episode: test[1],
+		episode := state.Result.Episode
+		claimed := rule.Apply(&state) && state.Result.Episode != ""
+		if claimed || state.Result.Episode != episode {
+			explanation.try(rule.Name(), state.Result.Episode)
+			explanation.win(rule.Name())
+		} else {
+			explanation.try(rule.Name(), "")
+		}
+		if claimed {
+			return state.Result
 		}
 	}
-	if test := sSeRegex.FindStringSubmatch(spaced); test != nil {
-		return EpisodeMetadata{
-			Season:  test[1],
-			Episode: test[2],
+	// range continuation of an already found episode, e.g. "Episode 01-02"
+	if state.Result.EpisodeEnd == "" {
+		state.Result.EpisodeEnd = findEpisodeRangeEnd(base, state.Result.Episode)
+	}
+	return state.Result
+}
+
+// builtinRules returns heuristics of the single file parser in the order they are tried
+func builtinRules() []Rule {
+	return []Rule{
+		builtinRule{RuleSERange, applySERange},
+		builtinRule{RuleSxE, applySxE},
+		builtinRule{RuleES, applyES},
+		builtinRule{RuleSE, applySE},
+		builtinRule{RuleCJKEpisode, applyCJKEpisode},
+		builtinRule{RuleAirDate, applyAirDate},
+		builtinRule{RuleEp, applyEp},
+		builtinRule{RuleEpisode, applyEpisode},
+		builtinRule{RuleLocalizedEpisode, applyLocalizedEpisode},
+		builtinRule{RuleDotSpace, applyDotSpace},
+		builtinRule{RuleSeasonWord, applySeasonWord},
+		builtinRule{RuleSeason, applySeason},
+		builtinRule{RuleLocalizedSeason, applyLocalizedSeason},
+		builtinRule{RuleCJKSeason, applyCJKSeason},
+		builtinRule{RulePart, applyPart},
+		builtinRule{RuleEpisodeRange, applyEpisodeRange},
+		builtinRule{RuleBracketNumber, applyBracketNumber},
+		builtinRule{RuleStartsWithNumber, applyStartsWithNumber},
+		builtinRule{RuleNumberWithSpace, applyNumberWithSpace},
+		builtinRule{RuleLastResort, applyLastResort},
+		builtinRule{RuleSeasonAsEpisode, applySeasonAsEpisode},
+		builtinRule{RuleBaseName, applyBaseName},
+	}
+}
+
+// popular formats claim the filename, e.g. "S01E02-E03"
+func applySERange(state *RuleState, explanation *Explanation) bool {
+	test := sSeRangeRegex.FindStringSubmatch(state.Base)
+	explanation.try(RuleSERange, firstMatch(test))
+	if test == nil || !isShortEpisodeRange(test[2], test[3]) {
+		return false
+	}
+	explanation.win(RuleSERange)
+	state.Result.Title = titleBefore(state.Base, test[0])
+	state.Result.Season = test[1]
+	state.Result.Episode = test[2]
+	state.Result.EpisodeEnd = test[3]
+	state.Result.Confidence = confidenceSE
+	return true
+}
+
+func applySxE(state *RuleState, explanation *Explanation) bool {
+	test := sSxERegex.FindStringSubmatch(state.Working)
+	explanation.try(RuleSxE, firstMatch(test))
+	if test == nil {
+		return false
+	}
+	if season, _ := strconv.Atoi(test[1]); season > state.parser.maxSeason {
+		return false
+	}
+	explanation.win(RuleSxE)
+	state.Result.Title = titleBefore(state.Working, test[0])
+	state.Result.Season = test[1]
+	state.Result.Episode = test[2]
+	state.Result.Confidence = confidenceSxE
+	return true
+}
+
+func applyES(state *RuleState, explanation *Explanation) bool {
+	test := sEsRegex.FindStringSubmatch(state.Working)
+	explanation.try(RuleES, firstMatch(test))
+	if test == nil {
+		return false
+	}
+	explanation.win(RuleES)
+	state.Result.Title = titleBefore(state.Working, test[0])
+	state.Result.Season = test[2]
+	state.Result.Episode = test[1]
+	state.Result.Confidence = confidenceES
+	return true
+}
+
+func applySE(state *RuleState, explanation *Explanation) bool {
+	test := sSeRegex.FindStringSubmatch(state.Working)
+	explanation.try(RuleSE, firstMatch(test))
+	if test == nil {
+		return false
+	}
+	explanation.win(RuleSE)
+	state.Result.Title = titleBefore(state.Working, test[0])
+	state.Result.Season = test[1]
+	state.Result.Episode = test[2]
+	state.Result.Confidence = confidenceSE
+	return true
+}
+
+func applyCJKEpisode(state *RuleState, explanation *Explanation) bool {
+	test := cjkEpisodeRegex.FindStringSubmatch(state.Working)
+	explanation.try(RuleCJKEpisode, firstMatch(test))
+	if test == nil {
+		return false
+	}
+	explanation.win(RuleCJKEpisode)
+	state.Result.Title = titleBefore(state.Working, test[0])
+	state.Result.Episode = test[1]
+	state.Result.Confidence = confidenceCJK
+	if seasonTest := cjkSeasonRegex.FindStringSubmatch(state.Working); seasonTest != nil {
+		state.Result.Season = seasonTest[1]
+		// title goes before both markers, e.g. "作品名 第3期 第05話"
+		if strings.Index(state.Working, seasonTest[0]) < strings.Index(state.Working, test[0]) {
+			state.Result.Title = titleBefore(state.Working, seasonTest[0])
 		}
 	}
-	if test := sEpRegex.FindStringSubmatch(spaced); test != nil {
-		resultEpisode = test[1]
-		spaced = sEpRegex.ReplaceAllLiteralString(spaced, "")
+	return true
+}
+
+func applyAirDate(state *RuleState, explanation *Explanation) bool {
+	date, dateMatch, ok := parseAirDate(state.Base)
+	explanation.try(RuleAirDate, dateMatch)
+	if !ok {
+		return false
 	}
-	if test := sEpisodeRegex.FindStringSubmatch(spaced); test != nil {
-		resultEpisode = test[1]
-		spaced = sEpisodeRegex.ReplaceAllLiteralString(spaced, "")
+	explanation.win(RuleAirDate)
+	state.Result.Title = titleBefore(state.Base, dateMatch)
+	state.Result.Episode = date.Format(airDateLayout)
+	state.Result.AirDate = date
+	state.Result.Confidence = confidenceAirDate
+	return true
+}
+
+// applyKeywordEpisode finds episode next to a keyword and removes it from the working string
+func applyKeywordEpisode(state *RuleState, explanation *Explanation, rule string, regex *regexp.Regexp, confidence float64) {
+	test := regex.FindStringSubmatch(state.Working)
+	explanation.try(rule, firstMatch(test))
+	if test == nil {
+		return
 	}
-	if test := eDotSpaceRegex.FindStringSubmatch(spaced); test != nil {
-		resultEpisode = test[1]
-		spaced = eDotSpaceRegex.ReplaceAllLiteralString(spaced, "")
+	state.Result.Episode = test[1]
+	state.Result.Confidence = confidence
+	explanation.win(rule)
+	// the delimiter after the number is kept, if the regex captures it
+	state.Working = regex.ReplaceAllString(state.Working, "${2}")
+}
+
+func applyEp(state *RuleState, explanation *Explanation) bool {
+	applyKeywordEpisode(state, explanation, RuleEp, sEpRegex, confidenceEp)
+	return false
+}
+
+func applyEpisode(state *RuleState, explanation *Explanation) bool {
+	applyKeywordEpisode(state, explanation, RuleEpisode, sEpisodeRegex, confidenceEpisode)
+	return false
+}
+
+func applyLocalizedEpisode(state *RuleState, explanation *Explanation) bool {
+	localized := state.parser.languageRegexes()
+	episode, match := findKeywordNumber(state.Working, localized.episodeForward, localized.episodeBackward)
+	explanation.try(RuleLocalizedEpisode, match)
+	if match != "" {
+		state.Result.Episode = episode
+		state.Result.Confidence = confidenceEpisode
+		explanation.win(RuleLocalizedEpisode)
+		state.Working = strings.Replace(state.Working, match, " ", 1)
+	}
+	return false
+}
+
+func applyDotSpace(state *RuleState, explanation *Explanation) bool {
+	applyKeywordEpisode(state, explanation, RuleDotSpace, eDotSpaceRegex, confidenceDotSpace)
+	return false
+}
+
+// goes before "season N", e.g. "2nd Season - 05" is not season 5
+func applySeasonWord(state *RuleState, explanation *Explanation) bool {
+	season, match := parseSeasonWord(state.Working)
+	explanation.try(RuleSeasonWord, match)
+	if match != "" {
+		state.Result.Season = season
+		state.Working = strings.Replace(state.Working, match, "  ", 1)
 	}
-	if test := sSeasonRegex.FindStringSubmatch(spaced); test != nil {
-		resultSeason = test[1]
-		spaced = sSeasonRegex.ReplaceAllLiteralString(spaced, "")
+	return false
+}
+
+func applySeason(state *RuleState, explanation *Explanation) bool {
+	test := sSeasonRegex.FindStringSubmatch(state.Working)
+	explanation.try(RuleSeason, firstMatch(test))
+	if test != nil {
+		state.Result.Season = test[1]
+		state.Working = sSeasonRegex.ReplaceAllLiteralString(state.Working, "")
+	}
+	return false
+}
+
+func applyLocalizedSeason(state *RuleState, explanation *Explanation) bool {
+	localized := state.parser.languageRegexes()
+	season, match := findKeywordNumber(state.Working, localized.seasonForward, localized.seasonBackward)
+	explanation.try(RuleLocalizedSeason, match)
+	if match != "" {
+		state.Result.Season = season
+		state.Working = strings.Replace(state.Working, match, " ", 1)
 	}
+	return false
+}
 
-	// remove brackets, they are almost always meaningless
+func applyCJKSeason(state *RuleState, explanation *Explanation) bool {
+	test := cjkSeasonRegex.FindStringSubmatch(state.Working)
+	explanation.try(RuleCJKSeason, firstMatch(test))
+	if test != nil {
+		state.Result.Season = test[1]
+		// split clusters, e.g. "作品名 第2季 05"
+		state.Working = strings.Replace(state.Working, test[0], "  ", 1)
+	}
+	return false
+}
+
+// part of a split season is not an episode, e.g. "Final Season Part 2", see parseSingle
+func applyPart(state *RuleState, explanation *Explanation) bool {
+	_, match := parsePart(state.Working)
+	explanation.try(RulePart, match)
+	if match != "" {
+		state.Working = strings.Replace(state.Working, match, "  ", 1)
+	}
+	return false
+}
+
+// multiple episodes in a single file, e.g. "Show - 01-02"
+func applyEpisodeRange(state *RuleState, explanation *Explanation) bool {
+	if state.Result.Episode != "" {
+		return false
+	}
+	ranges := episodeRangeRegex.FindAllStringSubmatch(state.Base, -1)
+	if len(ranges) != 1 || !isEpisodeRange(ranges[0][1], ranges[0][2]) {
+		explanation.try(RuleEpisodeRange, "")
+		return false
+	}
+	explanation.try(RuleEpisodeRange, ranges[0][0])
+	explanation.win(RuleEpisodeRange)
+	state.Result.Episode = ranges[0][1]
+	state.Result.EpisodeEnd = ranges[0][2]
+	state.Result.Confidence = confidenceStartsWithNum
+	state.Working = strings.Replace(state.Working, ranges[0][1]+" "+ranges[0][2], "", 1)
+	return false
+}
+
+// remove brackets, they are almost always meaningless
+// Brackets are kept if bracket stripping is disabled, but a number in brackets is still taken for episode
+func applyBracketNumber(state *RuleState, explanation *Explanation) bool {
+	spaced := state.Working
+	bracketNumber := ""
 	bracketDepth := 0
 	startIndex := 0
 	for i := 0; i < len(spaced); i++ {
@@ -93,78 +370,146 @@ func ParseSingleEpisodeMetadata(filename string) EpisodeMetadata {
 				break
 			}
 			if bracketDepth == 0 {
-				substr := substringStartEnd(spaced, startIndex+1, i)
+				// indexes are in bytes, brackets are ASCII so slicing is safe for non-latin names
+				substr := spaced[startIndex+1 : i]
 				// if bracket's only content is a number, it is a good candidate for episode
-				if resultEpisode == "" && fullNumberRegex.MatchString(substr) && len(substr) <= 3 {
-					resultEpisode = substr
+				isEpisode := state.Result.Episode == "" && fullNumberRegex.MatchString(substr) && len(substr) <= 3
+				if isEpisode {
+					state.Result.Episode = substr
+					state.Result.Confidence = confidenceBracketNumber
+					bracketNumber = substr
+					explanation.win(RuleBracketNumber)
+				}
+				if state.parser.stripBrackets || isEpisode {
+					spaced = spaced[:startIndex] + spaced[i+1:]
+					i = -1
 				}
-				spaced = substringStartEnd(spaced, 0, startIndex) + substringStart(spaced, i+1)
-				i = -1
 				continue
 			}
 		}
 	}
+	state.Working = spaced
+	explanation.try(RuleBracketNumber, bracketNumber)
+	return false
+}
 
-	// trim and split into clusters
-	spaced = strings.Trim(spaced, " ")
-	clusters := clusterRegex.Split(spaced, -1)
-
-	// (episode) find a single cluster starting with a number
-	if resultEpisode == "" {
-		clustersStartingWithNumber := 0
-		lastNumber := ""
-		lastCluster := -1
-		for i, cluster := range clusters {
-			if test := startsWithNumberRegex.FindStringSubmatch(cluster); test != nil {
-				clustersStartingWithNumber++
-				lastNumber = test[1]
-				lastCluster = i
-			}
-		}
-		if clustersStartingWithNumber == 1 {
-			resultEpisode = lastNumber
-			clusters[lastCluster] = strings.Replace(clusters[lastCluster], lastNumber, "", 1)
+// (episode) find a single cluster starting with a number
+func applyStartsWithNumber(state *RuleState, explanation *Explanation) bool {
+	clusters := state.getClusters()
+	if state.Result.Episode != "" {
+		return false
+	}
+	clustersStartingWithNumber := 0
+	lastNumber := ""
+	lastCluster := -1
+	for i, cluster := range clusters {
+		if test := startsWithNumberRegex.FindStringSubmatch(cluster); test != nil {
+			clustersStartingWithNumber++
+			lastNumber = test[1]
+			lastCluster = i
 		}
 	}
+	if clustersStartingWithNumber != 1 {
+		explanation.try(RuleStartsWithNumber, "")
+		return false
+	}
+	explanation.try(RuleStartsWithNumber, lastNumber)
+	explanation.win(RuleStartsWithNumber)
+	state.Result.Episode = lastNumber
+	state.Result.Confidence = confidenceStartsWithNum
+	state.setCluster(lastCluster, strings.Replace(clusters[lastCluster], lastNumber, "", 1))
+	return false
+}
 
-	// (episode) find a single cluster with a single number
-	if resultEpisode == "" {
-		clustersWithNumbers := 0
-		lastNumber := ""
-		lastCluster := -1
-		for i, cluster := range clusters {
-			if test := numberWithSpaceRegex.FindAllStringSubmatch(cluster, -1); test != nil && len(test) == 1 {
-				clustersWithNumbers++
-				lastNumber = test[0][1]
-				lastCluster = i
-			}
-		}
-		if clustersWithNumbers == 1 {
-			resultEpisode = lastNumber
-			clusters[lastCluster] = strings.Replace(clusters[lastCluster], lastNumber, "", 1)
+// (episode) find a single cluster with a single number
+func applyNumberWithSpace(state *RuleState, explanation *Explanation) bool {
+	clusters := state.getClusters()
+	if state.Result.Episode != "" {
+		return false
+	}
+	clustersWithNumbers := 0
+	lastNumber := ""
+	lastNumberCount := 0
+	lastCluster := -1
+	for i, cluster := range clusters {
+		if number, count := repeatedNumber(cluster); count > 0 {
+			clustersWithNumbers++
+			lastNumber = number
+			lastNumberCount = count
+			lastCluster = i
 		}
 	}
+	if clustersWithNumbers != 1 {
+		explanation.try(RuleNumberWithSpace, "")
+		return false
+	}
+	explanation.try(RuleNumberWithSpace, lastNumber)
+	explanation.win(RuleNumberWithSpace)
+	state.Result.Episode = lastNumber
+	state.Result.Confidence = confidenceNumberWithSpace
+	state.setCluster(lastCluster, strings.Replace(clusters[lastCluster], lastNumber, "", lastNumberCount))
+	return false
+}
 
-	// last resort
-	if len(clusters) > 0 {
-		if resultSeason == "" {
-			resultSeason = strings.Trim(clusters[0], " ")
-		}
-		if len(clusters) == 2 {
-			if resultEpisode == "" {
-				resultEpisode = strings.Trim(clusters[1], " ")
-			}
+// repeatedNumber returns the only number of the cluster and how many times it occurs, count is 0 if there is none
+// The same number repeated is still a single number, e.g. "nartsiss 01-01"
+func repeatedNumber(cluster string) (string, int) {
+	test := numberWithSpaceRegex.FindAllStringSubmatch(cluster, -1)
+	if len(test) == 0 {
+		return "", 0
+	}
+	for _, match := range test[1:] {
+		if match[1] != test[0][1] {
+			return "", 0
 		}
 	}
-	if resultEpisode == "" && resultSeason != "" {
-		resultEpisode = resultSeason
-		resultSeason = ""
+	return test[0][1], len(test)
+}
+
+// the first cluster is the title, the second one is the episode if nothing else was found
+func applyLastResort(state *RuleState, explanation *Explanation) bool {
+	clusters := state.getClusters()
+	if len(clusters) == 0 {
+		return false
+	}
+	if state.Result.Title == "" {
+		state.Result.Title = removeTrailingYear(strings.Trim(clusters[0], " "))
 	}
-	if resultEpisode == "" {
-		resultEpisode = strings.Trim(base, " ")
+	if len(clusters) == 2 && state.Result.Episode == "" {
+		state.Result.Episode = strings.Trim(clusters[1], " ")
+		state.Result.Confidence = confidenceLastResort
+		explanation.try(RuleLastResort, state.Result.Episode)
+		explanation.win(RuleLastResort)
 	}
-	return EpisodeMetadata{
-		Season:  resultSeason,
-		Episode: resultEpisode,
+	return false
+}
+
+func applySeasonAsEpisode(state *RuleState, explanation *Explanation) bool {
+	if state.Result.Episode != "" {
+		return false
+	}
+	if state.Result.Season != "" {
+		state.Result.Episode = state.Result.Season
+		state.Result.Season = ""
+	} else if state.Result.Title != "" {
+		state.Result.Episode = state.Result.Title
+		state.Result.Title = ""
+	} else {
+		return false
+	}
+	state.Result.Confidence = confidenceSeasonAsEpisode
+	explanation.try(RuleSeasonAsEpisode, state.Result.Episode)
+	explanation.win(RuleSeasonAsEpisode)
+	return false
+}
+
+func applyBaseName(state *RuleState, explanation *Explanation) bool {
+	if state.Result.Episode != "" {
+		return false
 	}
+	state.Result.Episode = strings.Trim(state.Base, " ")
+	state.Result.Confidence = confidenceBaseName
+	explanation.try(RuleBaseName, state.Result.Episode)
+	explanation.win(RuleBaseName)
+	return false
 }
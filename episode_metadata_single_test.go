@@ -7,7 +7,8 @@ import (
 
 func TestSingleEpisodeMetadata1(t *testing.T) {
 	metadata := ParseSingleEpisodeMetadata("Koroshi Ai - 06 (WEBDL 1080p HEVC AAC) Ukr DVO.mkv")
-	assert.Equal(t, metadata.Season, "Koroshi Ai")
+	assert.Equal(t, metadata.Title, "Koroshi Ai")
+	assert.Equal(t, metadata.Season, "")
 	assert.Equal(t, metadata.Episode, "06")
 }
 
@@ -31,61 +32,71 @@ func TestSingleEpisodeMetadata4(t *testing.T) {
 
 func TestSingleEpisodeMetadata5(t *testing.T) {
 	metadata := ParseSingleEpisodeMetadata("Hellsing - Ep. 05 - Brotherhood (480p DVDRip - DUAL Audio).mkv")
-	assert.Equal(t, metadata.Season, "Hellsing")
+	assert.Equal(t, metadata.Title, "Hellsing")
+	assert.Equal(t, metadata.Season, "")
 	assert.Equal(t, metadata.Episode, "05")
 }
 
 func TestSingleEpisodeMetadata6(t *testing.T) {
 	metadata := ParseSingleEpisodeMetadata("[Samir755] Hellsing Ultimate 02.mkv")
-	assert.Equal(t, metadata.Season, "Hellsing Ultimate")
+	assert.Equal(t, metadata.Title, "Hellsing Ultimate")
+	assert.Equal(t, metadata.Season, "")
 	assert.Equal(t, metadata.Episode, "02")
 }
 
 func TestSingleEpisodeMetadata7(t *testing.T) {
 	metadata := ParseSingleEpisodeMetadata("[CBM]_Hellsing_Ultimate_-_06_-_[1080p-AC3]_[1CB8EDB0].mkv")
-	assert.Equal(t, metadata.Season, "Hellsing Ultimate")
+	assert.Equal(t, metadata.Title, "Hellsing Ultimate")
+	assert.Equal(t, metadata.Season, "")
 	assert.Equal(t, metadata.Episode, "06")
 }
 
 func TestSingleEpisodeMetadata8(t *testing.T) {
 	metadata := ParseSingleEpisodeMetadata("[DB]Kabukimonogatari_-_NCED01_(10bit_BD1080p_x265).mkv")
-	assert.Equal(t, metadata.Season, "Kabukimonogatari")
+	assert.Equal(t, metadata.Title, "Kabukimonogatari")
+	assert.Equal(t, metadata.Season, "")
 	assert.Equal(t, metadata.Episode, "NCED01")
 }
 
 func TestSingleEpisodeMetadata9(t *testing.T) {
 	metadata := ParseSingleEpisodeMetadata("[DB]Nekomonogatari (Black)_-_Recap01_(10bit_BD1080p_x265).mkv")
-	assert.Equal(t, metadata.Season, "Nekomonogatari")
+	assert.Equal(t, metadata.Title, "Nekomonogatari")
+	assert.Equal(t, metadata.Season, "")
 	assert.Equal(t, metadata.Episode, "Recap01")
 }
 
 func TestSingleEpisodeMetadata10(t *testing.T) {
 	metadata := ParseSingleEpisodeMetadata("[AceAres] Suzumiya Haruhi-chan no Yuuutsu - Episode 06 [1080p BD Dual Audio x265].mkv")
-	assert.Equal(t, metadata.Season, "Suzumiya Haruhi chan no Yuuutsu")
+	assert.Equal(t, metadata.Title, "Suzumiya Haruhi chan no Yuuutsu")
+	assert.Equal(t, metadata.Season, "")
 	assert.Equal(t, metadata.Episode, "06")
 }
 
 func TestSingleEpisodeMetadata11(t *testing.T) {
 	metadata := ParseSingleEpisodeMetadata("[VCB-Studio] Suzumiya Haruhi no Gensou [01][Ma10p_1080p][x265_flac].mkv")
-	assert.Equal(t, metadata.Season, "Suzumiya Haruhi no Gensou")
+	assert.Equal(t, metadata.Title, "Suzumiya Haruhi no Gensou")
+	assert.Equal(t, metadata.Season, "")
 	assert.Equal(t, metadata.Episode, "01")
 }
 
 func TestSingleEpisodeMetadata12(t *testing.T) {
 	metadata := ParseSingleEpisodeMetadata("[VCB-Studio] Suzumiya Haruhi no Yuuutsu [26][Ma10p_1080p][x265_3flac].mkv")
-	assert.Equal(t, metadata.Season, "Suzumiya Haruhi no Yuuutsu")
+	assert.Equal(t, metadata.Title, "Suzumiya Haruhi no Yuuutsu")
+	assert.Equal(t, metadata.Season, "")
 	assert.Equal(t, metadata.Episode, "26")
 }
 
 func TestSingleEpisodeMetadata13(t *testing.T) {
 	metadata := ParseSingleEpisodeMetadata("[Underwater] Panty and Stocking with Garterbelt 07 - Trans-homers - The Stripping (BD 720p) [E9862607].mkv")
-	assert.Equal(t, metadata.Season, "Panty and Stocking with Garterbelt")
+	assert.Equal(t, metadata.Title, "Panty and Stocking with Garterbelt")
+	assert.Equal(t, metadata.Season, "")
 	assert.Equal(t, metadata.Episode, "07")
 }
 
 func TestSingleEpisodeMetadata14(t *testing.T) {
 	metadata := ParseSingleEpisodeMetadata("[Underwater] Panty and Stocking with Garterbelt OVA - In Sanitarybox (BD 720p) [3525A622].mkv")
-	assert.Equal(t, metadata.Season, "Panty and Stocking with Garterbelt OVA")
+	assert.Equal(t, metadata.Title, "Panty and Stocking with Garterbelt OVA")
+	assert.Equal(t, metadata.Season, "0")
 	assert.Equal(t, metadata.Episode, "In Sanitarybox")
 }
 
@@ -97,31 +108,36 @@ func TestSingleEpisodeMetadata15(t *testing.T) {
 
 func TestSingleEpisodeMetadata16(t *testing.T) {
 	metadata := ParseSingleEpisodeMetadata("Shingeki No Kyojin Oad 03.mkv")
-	assert.Equal(t, metadata.Season, "Shingeki No Kyojin Oad")
+	assert.Equal(t, metadata.Title, "Shingeki No Kyojin Oad")
+	assert.Equal(t, metadata.Season, "0")
 	assert.Equal(t, metadata.Episode, "03")
 }
 
 func TestSingleEpisodeMetadata17(t *testing.T) {
 	metadata := ParseSingleEpisodeMetadata("[gg]_Trapeze_-_06_[DAA1989B].mkv")
-	assert.Equal(t, metadata.Season, "Trapeze")
+	assert.Equal(t, metadata.Title, "Trapeze")
+	assert.Equal(t, metadata.Season, "")
 	assert.Equal(t, metadata.Episode, "06")
 }
 
 func TestSingleEpisodeMetadata18(t *testing.T) {
 	metadata := ParseSingleEpisodeMetadata("[gg]_Trapeze_-_07v2_[985067CA].mkv")
-	assert.Equal(t, metadata.Season, "Trapeze")
-	assert.Equal(t, metadata.Episode, "07v2")
+	assert.Equal(t, metadata.Title, "Trapeze")
+	assert.Equal(t, metadata.Season, "")
+	assert.Equal(t, metadata.Episode, "07")
 }
 
 func TestSingleEpisodeMetadata19(t *testing.T) {
 	metadata := ParseSingleEpisodeMetadata("[Fate-Subs]_Kuuchuu_Buranko_(Trapeze)_08_(1280x720_x264_AAC)_Sub_Ita.mp4")
-	assert.Equal(t, metadata.Season, "Kuuchuu Buranko")
+	assert.Equal(t, metadata.Title, "Kuuchuu Buranko")
+	assert.Equal(t, metadata.Season, "")
 	assert.Equal(t, metadata.Episode, "08")
 }
 
 func TestSingleEpisodeMetadata20(t *testing.T) {
 	metadata := ParseSingleEpisodeMetadata("[King] Ousama Ranking - 17 [1080p][D2DCB6D0].mkv")
-	assert.Equal(t, metadata.Season, "Ousama Ranking")
+	assert.Equal(t, metadata.Title, "Ousama Ranking")
+	assert.Equal(t, metadata.Season, "")
 	assert.Equal(t, metadata.Episode, "17")
 }
 
@@ -133,7 +149,8 @@ func TestSingleEpisodeMetadata21(t *testing.T) {
 
 func TestSingleEpisodeMetadata22(t *testing.T) {
 	metadata := ParseSingleEpisodeMetadata("[Nep_Blanc] Death Note 35.mkv")
-	assert.Equal(t, metadata.Season, "Death Note")
+	assert.Equal(t, metadata.Title, "Death Note")
+	assert.Equal(t, metadata.Season, "")
 	assert.Equal(t, metadata.Episode, "35")
 }
 
@@ -145,7 +162,8 @@ func TestSingleEpisodeMetadata23(t *testing.T) {
 
 func TestSingleEpisodeMetadata24(t *testing.T) {
 	metadata := ParseSingleEpisodeMetadata("Cowboy Bebop - 03 ITBD Remux.mkv")
-	assert.Equal(t, metadata.Season, "Cowboy Bebop")
+	assert.Equal(t, metadata.Title, "Cowboy Bebop")
+	assert.Equal(t, metadata.Season, "")
 	assert.Equal(t, metadata.Episode, "03")
 }
 
@@ -157,7 +175,8 @@ func TestSingleEpisodeMetadata25(t *testing.T) {
 
 func TestSingleEpisodeMetadata26(t *testing.T) {
 	metadata := ParseSingleEpisodeMetadata("season [ep1].mkv")
-	assert.Equal(t, metadata.Season, "season")
+	assert.Equal(t, metadata.Title, "season")
+	assert.Equal(t, metadata.Season, "")
 	assert.Equal(t, metadata.Episode, "1")
 }
 
@@ -168,13 +187,15 @@ func TestSingleEpisodeMetadata27(t *testing.T) {
 }
 func TestSingleEpisodeMetadata28(t *testing.T) {
 	metadata := ParseSingleEpisodeMetadata("[yt-dlp] Orient - 15 (AMZN 1920x1080 H.264 E-AC-3) [99D81F63].mkv")
-	assert.Equal(t, metadata.Season, "Orient")
+	assert.Equal(t, metadata.Title, "Orient")
+	assert.Equal(t, metadata.Season, "")
 	assert.Equal(t, metadata.Episode, "15")
 }
 
 func TestSingleEpisodeMetadata29(t *testing.T) {
 	metadata := ParseSingleEpisodeMetadata("[Anime Time] Durarara!! X2 Ketsu/[Anime Time] Durarara!! X2 Ketsu - 7.5.mkv")
-	assert.Equal(t, metadata.Season, "Durarara!! X2 Ketsu")
+	assert.Equal(t, metadata.Title, "Durarara!! X2 Ketsu")
+	assert.Equal(t, metadata.Season, "")
 	assert.Equal(t, metadata.Episode, "7.5")
 }
 
@@ -186,24 +207,389 @@ func TestSingleEpisodeMetadata30(t *testing.T) {
 
 func TestSingleEpisodeMetadata31(t *testing.T) {
 	metadata := ParseSingleEpisodeMetadata("[SubsPlease] Heion Sedai no Idaten-tachi - 01 (1080p) [28B342E5].mkv")
-	assert.Equal(t, metadata.Season, "Heion Sedai no Idaten tachi")
+	assert.Equal(t, metadata.Title, "Heion Sedai no Idaten tachi")
+	assert.Equal(t, metadata.Season, "")
 	assert.Equal(t, metadata.Episode, "01")
 }
 
 func TestSingleEpisodeMetadata32(t *testing.T) {
 	metadata := ParseSingleEpisodeMetadata("The Tatami Galaxy - Special E01 [1080p][x265][10-bit].mkv")
-	assert.Equal(t, metadata.Season, "The Tatami Galaxy")
+	assert.Equal(t, metadata.Title, "The Tatami Galaxy")
+	assert.Equal(t, metadata.Season, "0")
 	assert.Equal(t, metadata.Episode, "Special E01")
 }
 
 func TestSingleEpisodeMetadata33(t *testing.T) {
 	metadata := ParseSingleEpisodeMetadata("01. Sayonara Zetsubou Sensei [720p Hi10p AAC BDRip][kuchikirukia] [E24E9EB2].mkv")
-	assert.Equal(t, metadata.Season, "Sayonara Zetsubou Sensei")
+	assert.Equal(t, metadata.Title, "Sayonara Zetsubou Sensei")
+	assert.Equal(t, metadata.Season, "")
 	assert.Equal(t, metadata.Episode, "01")
 }
 
 func TestSingleEpisodeMetadata34(t *testing.T) {
 	metadata := ParseSingleEpisodeMetadata("[Commie] Sayonara Zetsubou Sensei (2012) - BD Special [BD 720p AAC] [BEA51F1F].mkv")
-	assert.Equal(t, metadata.Season, "Sayonara Zetsubou Sensei")
+	assert.Equal(t, metadata.Title, "Sayonara Zetsubou Sensei")
+	assert.Equal(t, metadata.Season, "0")
 	assert.Equal(t, metadata.Episode, "BD Special")
 }
+
+func TestSingleEpisodeMetadataConfidence1(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("[Judas] Hunter x Hunter (2011) - S01E012.mkv")
+	assert.Equal(t, metadata.Confidence, confidenceSE)
+}
+
+func TestSingleEpisodeMetadataConfidence2(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("[what][is][this].mkv")
+	assert.Equal(t, metadata.Confidence, confidenceBaseName)
+}
+
+func TestSingleEpisodeMetadataConfidence3(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("[Nep_Blanc] Death Note 35.txt")
+	assert.Equal(t, metadata.Confidence, 0.0)
+}
+
+func TestSingleEpisodeMetadataConfidence4(t *testing.T) {
+	high := ParseSingleEpisodeMetadata("Attack On Titan Season 3 - Episode 19.mkv")
+	low := ParseSingleEpisodeMetadata("[Underwater] Panty and Stocking with Garterbelt OVA - In Sanitarybox (BD 720p) [3525A622].mkv")
+	if high.Confidence <= low.Confidence {
+		t.Fatalf("Expected %f to be greater than %f", high.Confidence, low.Confidence)
+	}
+}
+
+func TestSingleEpisodeMetadataExplained1(t *testing.T) {
+	metadata, explanation := ParseSingleEpisodeMetadataExplained("[Judas] Hunter x Hunter (2011) - S01E012.mkv")
+	assert.Equal(t, metadata, ParseSingleEpisodeMetadata("[Judas] Hunter x Hunter (2011) - S01E012.mkv"))
+	assert.Equal(t, explanation.Winner, RuleSE)
+	assert.Equal(t, len(explanation.Rules), 4)
+	assert.Equal(t, explanation.Rules[1].Rule, RuleSxE)
+	assert.Equal(t, explanation.Rules[1].Match, "")
+	assert.Equal(t, explanation.Rules[3].Match, "S01E012")
+}
+
+func TestSingleEpisodeMetadataExplained2(t *testing.T) {
+	_, explanation := ParseSingleEpisodeMetadataExplained("[VCB-Studio] Suzumiya Haruhi no Gensou [01][Ma10p_1080p][x265_flac].mkv")
+	assert.Equal(t, explanation.Winner, RuleBracketNumber)
+}
+
+func TestSingleEpisodeMetadataExplained3(t *testing.T) {
+	_, explanation := ParseSingleEpisodeMetadataExplained("[Underwater] Panty and Stocking with Garterbelt OVA - In Sanitarybox (BD 720p) [3525A622].mkv")
+	assert.Equal(t, explanation.Winner, RuleLastResort)
+	assert.Equal(t, explanation.Rules[len(explanation.Rules)-1].Match, "In Sanitarybox")
+}
+
+func TestSingleEpisodeMetadataRange1(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("Show.S01E01E02.mkv")
+	assert.Equal(t, metadata.Season, "01")
+	assert.Equal(t, metadata.Episode, "01")
+	assert.Equal(t, metadata.EpisodeEnd, "02")
+}
+
+func TestSingleEpisodeMetadataRange2(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("[Group] Show - S02E05-06 [1080p].mkv")
+	assert.Equal(t, metadata.Season, "02")
+	assert.Equal(t, metadata.Episode, "05")
+	assert.Equal(t, metadata.EpisodeEnd, "06")
+}
+
+func TestSingleEpisodeMetadataRange3(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("Show - S01E01-E02 - Pilot.mkv")
+	assert.Equal(t, metadata.Episode, "01")
+	assert.Equal(t, metadata.EpisodeEnd, "02")
+}
+
+func TestSingleEpisodeMetadataRange4(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("Show - 01-02.mkv")
+	assert.Equal(t, metadata.Title, "Show")
+	assert.Equal(t, metadata.Season, "")
+	assert.Equal(t, metadata.Episode, "01")
+	assert.Equal(t, metadata.EpisodeEnd, "02")
+}
+
+func TestSingleEpisodeMetadataRange5(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("[Judas] Hunter x Hunter (2011) - S01E012.mkv")
+	assert.Equal(t, metadata.EpisodeEnd, "")
+	metadata = ParseSingleEpisodeMetadata("Show - 02-01.mkv")
+	assert.Equal(t, metadata.EpisodeEnd, "")
+}
+
+func TestSingleEpisodeMetadataRange6(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("Show - Episode 01-02.mkv")
+	assert.Equal(t, metadata.Episode, "01")
+	assert.Equal(t, metadata.EpisodeEnd, "02")
+}
+
+func TestSingleEpisodeMetadataRange7(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("Show - S01E05 - 1080p.mkv")
+	assert.Equal(t, metadata.Season, "01")
+	assert.Equal(t, metadata.Episode, "05")
+	assert.Equal(t, metadata.EpisodeEnd, "")
+	metadata = ParseSingleEpisodeMetadata("Show S01E03 - 720p.mkv")
+	assert.Equal(t, metadata.Episode, "03")
+	assert.Equal(t, metadata.EpisodeEnd, "")
+	metadata = ParseSingleEpisodeMetadata("Show S01E03 - 20 Years Later.mkv")
+	assert.Equal(t, metadata.Episode, "03")
+	assert.Equal(t, metadata.EpisodeEnd, "")
+}
+
+func TestSingleEpisodeMetadataRepeatedNumber(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("Show 05 05.mkv")
+	assert.Equal(t, metadata.Title, "Show")
+	assert.Equal(t, metadata.Episode, "05")
+	number, count := repeatedNumber("nartsiss 01 01")
+	assert.Equal(t, number, "01")
+	assert.Equal(t, count, 2)
+	_, count = repeatedNumber("Show 05 06")
+	assert.Equal(t, count, 0)
+}
+
+func TestSingleEpisodeMetadataKind1(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("[DB]Kabukimonogatari_-_NCED01_(10bit_BD1080p_x265).mkv")
+	assert.Equal(t, metadata.Kind, KindEnding)
+	metadata = ParseSingleEpisodeMetadata("[Judas] Hunter x Hunter (2011) - S01E012.mkv")
+	assert.Equal(t, metadata.Kind, KindRegular)
+}
+
+func TestSingleEpisodeMetadataKind2(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("[Underwater] Panty and Stocking with Garterbelt OVA - In Sanitarybox (BD 720p) [3525A622].mkv")
+	assert.Equal(t, metadata.Kind, KindOVA)
+}
+
+func TestSingleEpisodeMetadataDecimal1(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("Show - 13.5 - Recap.mkv")
+	assert.Equal(t, metadata.Title, "Show")
+	assert.Equal(t, metadata.Season, "")
+	assert.Equal(t, metadata.Episode, "13.5")
+	assert.Equal(t, metadata.Interstitial, true)
+}
+
+func TestSingleEpisodeMetadataDecimal2(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("[DB]Nekomonogatari (Black)_-_Recap01_(10bit_BD1080p_x265).mkv")
+	assert.Equal(t, metadata.Interstitial, true)
+	metadata = ParseSingleEpisodeMetadata("[Judas] Hunter x Hunter (2011) - S01E012.mkv")
+	assert.Equal(t, metadata.Interstitial, false)
+}
+
+func TestSingleEpisodeMetadataDecimal3(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("Show 12x5.mkv")
+	assert.Equal(t, metadata.Season, "12")
+	assert.Equal(t, metadata.Episode, "5")
+	assert.Equal(t, metadata.Interstitial, false)
+}
+
+func TestSingleEpisodeMetadataDecimal4(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("Show Episode 12.5.mkv")
+	assert.Equal(t, metadata.Title, "Show")
+	assert.Equal(t, metadata.Episode, "12.5")
+	assert.Equal(t, metadata.Interstitial, true)
+	metadata = ParseSingleEpisodeMetadata("Show Ep 07.5 - Recap.mkv")
+	assert.Equal(t, metadata.Title, "Show")
+	assert.Equal(t, metadata.Episode, "07.5")
+	assert.Equal(t, metadata.Interstitial, true)
+	// resolution after the episode is not a fraction
+	metadata = ParseSingleEpisodeMetadata("Show Ep05.1080p.mkv")
+	assert.Equal(t, metadata.Episode, "05")
+	assert.Equal(t, metadata.Interstitial, false)
+}
+
+func TestSingleEpisodeMetadataTitle1(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("[Judas] Hunter x Hunter (2011) - S01E012.mkv")
+	assert.Equal(t, metadata.Title, "Hunter x Hunter")
+	assert.Equal(t, metadata.Season, "01")
+}
+
+func TestSingleEpisodeMetadataTitle2(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("The.Office.S02E03.720p.mkv")
+	assert.Equal(t, metadata.Title, "The Office")
+	assert.Equal(t, metadata.Season, "02")
+	assert.Equal(t, metadata.Episode, "03")
+}
+
+func TestSingleEpisodeMetadataTitle3(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("01.mkv")
+	assert.Equal(t, metadata.Title, "")
+	metadata = ParseSingleEpisodeMetadata("Show season 2.mkv")
+	assert.Equal(t, metadata.Title, "Show")
+	assert.Equal(t, metadata.Season, "")
+	assert.Equal(t, metadata.Episode, "2")
+}
+
+func TestSingleEpisodeMetadataRelease(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("[SubsPlease] Heion Sedai no Idaten-tachi - 01 (1080p) [28B342E5].mkv")
+	assert.Equal(t, metadata.Release.Group, "SubsPlease")
+	assert.Equal(t, metadata.Release.Resolution, "1080p")
+	assert.Equal(t, ParseSingleEpisodeMetadata("[SubsPlease] Show - 01 (1080p).txt").Release, ReleaseInfo{})
+}
+
+func TestSingleEpisodeMetadataVersion1(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("[SubsPlease] Show - 05v2 (1080p) [28B342E5].mkv")
+	assert.Equal(t, metadata.Episode, "05")
+	assert.Equal(t, metadata.Version, 2)
+	metadata = ParseSingleEpisodeMetadata("[Judas] Show - S01E05v3.mkv")
+	assert.Equal(t, metadata.Episode, "05")
+	assert.Equal(t, metadata.Version, 3)
+}
+
+func TestSingleEpisodeMetadataVersion2(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("[Group] Show - 05 [v3].mkv")
+	assert.Equal(t, metadata.Episode, "05")
+	assert.Equal(t, metadata.Version, 3)
+	metadata = ParseSingleEpisodeMetadata("[Group] Show - 05.mkv")
+	assert.Equal(t, metadata.Version, 0)
+}
+
+func TestSingleEpisodeMetadataAirDate1(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("Show.2023.05.14.Guest.Name.mkv")
+	assert.Equal(t, metadata.Title, "Show")
+	assert.Equal(t, metadata.Season, "")
+	assert.Equal(t, metadata.Episode, "2023-05-14")
+	assert.Equal(t, metadata.AirDate.Format("2006-01-02"), "2023-05-14")
+}
+
+func TestSingleEpisodeMetadataAirDate2(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("Late Show - 14.05.2023 (1080p).mkv")
+	assert.Equal(t, metadata.Title, "Late Show")
+	assert.Equal(t, metadata.Episode, "2023-05-14")
+	metadata = ParseSingleEpisodeMetadata("The.Daily.Show.S28E50.2023.05.14.mkv")
+	assert.Equal(t, metadata.Season, "28")
+	assert.Equal(t, metadata.Episode, "50")
+	assert.Equal(t, metadata.AirDate.Format("2006-01-02"), "2023-05-14")
+	metadata = ParseSingleEpisodeMetadata("[Judas] Hunter x Hunter (2011) - S01E012.mkv")
+	assert.Equal(t, metadata.AirDate.IsZero(), true)
+}
+
+func TestSingleEpisodeMetadataYear1(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("Show 2011 - 05.mkv")
+	assert.Equal(t, metadata.Title, "Show")
+	assert.Equal(t, metadata.Episode, "05")
+	assert.Equal(t, metadata.Year, 2011)
+	metadata = ParseSingleEpisodeMetadata("Show 2011 05.mkv")
+	assert.Equal(t, metadata.Title, "Show")
+	assert.Equal(t, metadata.Episode, "05")
+	assert.Equal(t, metadata.Year, 2011)
+}
+
+func TestSingleEpisodeMetadataYear2(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("Show.2019.S01E03.mkv")
+	assert.Equal(t, metadata.Title, "Show")
+	assert.Equal(t, metadata.Season, "01")
+	assert.Equal(t, metadata.Episode, "03")
+	assert.Equal(t, metadata.Year, 2019)
+	metadata = ParseSingleEpisodeMetadata("[Judas] Hunter x Hunter (2011) - S01E012.mkv")
+	assert.Equal(t, metadata.Year, 2011)
+}
+
+func TestSingleEpisodeMetadataYear3(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("Show 2011.mkv")
+	assert.Equal(t, metadata.Episode, "2011")
+	assert.Equal(t, metadata.Year, 0)
+}
+
+func TestSingleEpisodeMetadataCJK1(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("作品名 第12話.mkv")
+	assert.Equal(t, metadata.Title, "作品名")
+	assert.Equal(t, metadata.Season, "")
+	assert.Equal(t, metadata.Episode, "12")
+	metadata = ParseSingleEpisodeMetadata("作品名 第１２话.mkv")
+	assert.Equal(t, metadata.Episode, "12")
+	metadata = ParseSingleEpisodeMetadata("作品名 第十二話.mkv")
+	assert.Equal(t, metadata.Episode, "12")
+}
+
+func TestSingleEpisodeMetadataCJK2(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("作品名 第3期 第05集.mkv")
+	assert.Equal(t, metadata.Title, "作品名")
+	assert.Equal(t, metadata.Season, "3")
+	assert.Equal(t, metadata.Episode, "05")
+	metadata = ParseSingleEpisodeMetadata("[字幕组] 作品名 第二季 05 [1080p].mkv")
+	assert.Equal(t, metadata.Title, "作品名")
+	assert.Equal(t, metadata.Season, "2")
+	assert.Equal(t, metadata.Episode, "05")
+}
+
+func TestSingleEpisodeMetadataLanguage1(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("Сезон 2 Серия 5.mkv")
+	assert.Equal(t, metadata.Season, "2")
+	assert.Equal(t, metadata.Episode, "5")
+	metadata = ParseSingleEpisodeMetadata("La Casa de Papel Temporada 1 Capítulo 3.mkv")
+	assert.Equal(t, metadata.Title, "La Casa de Papel")
+	assert.Equal(t, metadata.Season, "1")
+	assert.Equal(t, metadata.Episode, "3")
+}
+
+func TestSingleEpisodeMetadataLanguage2(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("Dark Staffel 2 Folge 7.mkv")
+	assert.Equal(t, metadata.Title, "Dark")
+	assert.Equal(t, metadata.Season, "2")
+	assert.Equal(t, metadata.Episode, "7")
+	metadata = ParseSingleEpisodeMetadata("Saison 1 Épisode 4.mkv")
+	assert.Equal(t, metadata.Season, "1")
+	assert.Equal(t, metadata.Episode, "4")
+	metadata = ParseSingleEpisodeMetadata("Шоу - 2 сезон 11 серия.mkv")
+	assert.Equal(t, metadata.Title, "Шоу")
+	assert.Equal(t, metadata.Season, "2")
+	assert.Equal(t, metadata.Episode, "11")
+}
+
+func TestSingleEpisodeMetadataSeasonWord1(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("[SubsPlease] Overlord III - 05 (1080p).mkv")
+	assert.Equal(t, metadata.Title, "Overlord")
+	assert.Equal(t, metadata.Season, "3")
+	assert.Equal(t, metadata.Episode, "05")
+	metadata = ParseSingleEpisodeMetadata("[SubsPlease] Mob Psycho 100 II - 03 (1080p).mkv")
+	assert.Equal(t, metadata.Title, "Mob Psycho 100")
+	assert.Equal(t, metadata.Season, "2")
+	assert.Equal(t, metadata.Episode, "03")
+}
+
+func TestSingleEpisodeMetadataSeasonWord2(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("[SubsPlease] Kaguya-sama 2nd Season - 05 (1080p).mkv")
+	assert.Equal(t, metadata.Title, "Kaguya sama")
+	assert.Equal(t, metadata.Season, "2")
+	assert.Equal(t, metadata.Episode, "05")
+	metadata = ParseSingleEpisodeMetadata("Show Second Season - 07.mkv")
+	assert.Equal(t, metadata.Season, "2")
+	assert.Equal(t, metadata.Episode, "07")
+}
+
+func TestSingleEpisodeMetadataSeasonWord3(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("[SubsPlease] Shingeki no Kyojin The Final Season Part 2 - 05 (1080p).mkv")
+	assert.Equal(t, metadata.Title, "Shingeki no Kyojin")
+	assert.Equal(t, metadata.Season, "Final")
+	assert.Equal(t, metadata.Part, 2)
+	assert.Equal(t, metadata.Episode, "05")
+	metadata = ParseSingleEpisodeMetadata("Show Season II Cour 2 06.mkv")
+	assert.Equal(t, metadata.Season, "2")
+	assert.Equal(t, metadata.Part, 2)
+	assert.Equal(t, metadata.Episode, "06")
+}
+
+func TestSingleEpisodeMetadataDirs1(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("Show/Season 02/05.mkv")
+	assert.Equal(t, metadata.Title, "Show")
+	assert.Equal(t, metadata.Season, "2")
+	assert.Equal(t, metadata.Episode, "05")
+	metadata = ParseSingleEpisodeMetadata("Show/Season 02/Show - S03E05.mkv")
+	assert.Equal(t, metadata.Season, "03")
+}
+
+func TestSingleEpisodeMetadataDirs2(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("Show/Specials/Show - 01.mkv")
+	assert.Equal(t, metadata.Season, "0")
+	assert.Equal(t, metadata.Kind, KindSpecial)
+	metadata = ParseSingleEpisodeMetadata("Show/Season 1/Extras/Behind the scenes.mkv")
+	assert.Equal(t, metadata.Season, "1")
+	assert.Equal(t, metadata.Kind, KindExtra)
+	assert.Equal(t, ParseSingleEpisodeMetadata("Show/Season 1/readme.txt"), EpisodeMetadata{})
+}
+
+func TestSingleEpisodeMetadataDirs3(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("/home/alice/Downloads/05.mkv")
+	assert.Equal(t, metadata.Title, "")
+	assert.Equal(t, metadata.Episode, "05")
+	metadata = ParseSingleEpisodeMetadata("/mnt/s3/anime/Show - 05.mkv")
+	assert.Equal(t, metadata.Title, "Show")
+	assert.Equal(t, metadata.Season, "")
+	metadata = ParseSingleEpisodeMetadata("/mnt/media/Show/Season 2/05.mkv")
+	assert.Equal(t, metadata.Title, "Show")
+	assert.Equal(t, metadata.Season, "2")
+}
@@ -0,0 +1,89 @@
+package roflmeta
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var decimalNumberRegex = regexp.MustCompile("^\\d+(?:\\.\\d+)?$")
+var recapRegex = regexp.MustCompile("(?i)(?:^|\\P{L})recap")
+
+// parseDecimal parses values like "12" or "12.5", anything else is rejected
+func parseDecimal(value string) (float64, bool) {
+	if !decimalNumberRegex.MatchString(value) {
+		return 0, false
+	}
+	result, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, false
+	}
+	return result, true
+}
+
+// isInterstitial checks whether the episode is a recap or a half episode
+func isInterstitial(base string, episode string) bool {
+	if value, ok := parseDecimal(episode); ok && value != math.Trunc(value) {
+		return true
+	}
+	return recapRegex.MatchString(base)
+}
+
+// EpisodeValue returns numeric value of the episode, e.g. 12.5 for "12.5"
+// Use it to sort episodes, so that half episodes are placed between regular ones
+// The second value is false if episode is not a number
+func (m EpisodeMetadata) EpisodeValue() (float64, bool) {
+	return parseDecimal(m.Episode)
+}
+
+// canonicalNumber strips leading zeros of numeric values, e.g. "012" becomes "12" and "07.5" becomes "7.5"
+// Non-numeric values are returned as is
+func canonicalNumber(value string) string {
+	if !decimalNumberRegex.MatchString(value) {
+		return value
+	}
+	result := strings.TrimLeft(value, "0")
+	if result == "" || result[0] == '.' {
+		result = "0" + result
+	}
+	return result
+}
+
+// SeasonNumber returns season as an integer
+// The second value is false if season is not an integer, e.g. it is empty or a season name
+func (m EpisodeMetadata) SeasonNumber() (int, bool) {
+	return parseInteger(m.Season)
+}
+
+// EpisodeNumber returns episode as an integer
+// The second value is false if episode is not an integer, see EpisodeValue for decimal episodes
+func (m EpisodeMetadata) EpisodeNumber() (int, bool) {
+	return parseInteger(m.Episode)
+}
+
+// CanonicalSeason returns season without leading zeros if it is numeric, otherwise the season as is
+func (m EpisodeMetadata) CanonicalSeason() string {
+	return canonicalNumber(m.Season)
+}
+
+// CanonicalEpisode returns episode without leading zeros if it is numeric, otherwise the episode as is
+func (m EpisodeMetadata) CanonicalEpisode() string {
+	return canonicalNumber(m.Episode)
+}
+
+// SameEpisode compares canonical seasons and episodes, e.g. results for "S01E012" and "1x12" are the same episode
+func (m EpisodeMetadata) SameEpisode(other EpisodeMetadata) bool {
+	return m.CanonicalSeason() == other.CanonicalSeason() && m.CanonicalEpisode() == other.CanonicalEpisode()
+}
+
+func parseInteger(value string) (int, bool) {
+	if !fullNumberRegex.MatchString(value) {
+		return 0, false
+	}
+	result, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, false
+	}
+	return result, true
+}
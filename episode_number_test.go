@@ -0,0 +1,77 @@
+package roflmeta
+
+import (
+	"github.com/go-playground/assert/v2"
+	"sort"
+	"testing"
+)
+
+func TestEpisodeValue(t *testing.T) {
+	value, ok := EpisodeMetadata{Episode: "12.5"}.EpisodeValue()
+	assert.Equal(t, ok, true)
+	assert.Equal(t, value, 12.5)
+
+	value, ok = EpisodeMetadata{Episode: "012"}.EpisodeValue()
+	assert.Equal(t, ok, true)
+	assert.Equal(t, value, 12.0)
+
+	_, ok = EpisodeMetadata{Episode: "12x5"}.EpisodeValue()
+	assert.Equal(t, ok, false)
+
+	_, ok = EpisodeMetadata{Episode: "OVA"}.EpisodeValue()
+	assert.Equal(t, ok, false)
+}
+
+func TestEpisodeValueSort(t *testing.T) {
+	metadataArr := []EpisodeMetadata{{Episode: "13"}, {Episode: "12.5"}, {Episode: "12"}, {Episode: "2"}}
+	sort.Slice(metadataArr, func(i, j int) bool {
+		a, _ := metadataArr[i].EpisodeValue()
+		b, _ := metadataArr[j].EpisodeValue()
+		return a < b
+	})
+	assert.Equal(t, metadataArr[0].Episode, "2")
+	assert.Equal(t, metadataArr[1].Episode, "12")
+	assert.Equal(t, metadataArr[2].Episode, "12.5")
+	assert.Equal(t, metadataArr[3].Episode, "13")
+}
+
+func TestInterstitial(t *testing.T) {
+	assert.Equal(t, isInterstitial("Show - 07.5", "07.5"), true)
+	assert.Equal(t, isInterstitial("Show - Recap", "Recap"), true)
+	assert.Equal(t, isInterstitial("Show - 07", "07"), false)
+	assert.Equal(t, isInterstitial("Precaption - 07", "07"), false)
+}
+
+func TestNumericAccessors(t *testing.T) {
+	metadata := ParseSingleEpisodeMetadata("[Judas] Hunter x Hunter (2011) - S01E012.mkv")
+	season, ok := metadata.SeasonNumber()
+	assert.Equal(t, ok, true)
+	assert.Equal(t, season, 1)
+	episode, ok := metadata.EpisodeNumber()
+	assert.Equal(t, ok, true)
+	assert.Equal(t, episode, 12)
+
+	metadata = ParseSingleEpisodeMetadata("[Samir755] Hellsing Ultimate 02.mkv")
+	_, ok = metadata.SeasonNumber()
+	assert.Equal(t, ok, false)
+
+	_, ok = EpisodeMetadata{Episode: "12.5"}.EpisodeNumber()
+	assert.Equal(t, ok, false)
+}
+
+func TestCanonical(t *testing.T) {
+	assert.Equal(t, EpisodeMetadata{Episode: "012"}.CanonicalEpisode(), "12")
+	assert.Equal(t, EpisodeMetadata{Episode: "00"}.CanonicalEpisode(), "0")
+	assert.Equal(t, EpisodeMetadata{Episode: "07.5"}.CanonicalEpisode(), "7.5")
+	assert.Equal(t, EpisodeMetadata{Episode: "OVA"}.CanonicalEpisode(), "OVA")
+	assert.Equal(t, EpisodeMetadata{Season: "Hellsing Ultimate"}.CanonicalSeason(), "Hellsing Ultimate")
+	assert.Equal(t, EpisodeMetadata{Season: "01"}.CanonicalSeason(), "1")
+}
+
+func TestSameEpisode(t *testing.T) {
+	a := ParseSingleEpisodeMetadata("Show - S01E012.mkv")
+	b := ParseSingleEpisodeMetadata("Show - 1x12.mkv")
+	c := ParseSingleEpisodeMetadata("Show - 1x13.mkv")
+	assert.Equal(t, a.SameEpisode(b), true)
+	assert.Equal(t, a.SameEpisode(c), false)
+}
@@ -0,0 +1,63 @@
+package roflmeta
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// the end must be followed by a delimiter, so that "S01E05 - 1080p" is not a range
+var sSeRangeRegex = regexp.MustCompile("(?i)s\\s*(\\d+)\\s*e\\s*(\\d+)\\s*(?:-\\s*e?|e)\\s*(\\d+)(?:e|[\\s._\\])]|$)")
+var episodeRangeRegex = regexp.MustCompile("(?:^|[\\s_\\[(])(\\d{1,4})-(\\d{1,4})(?:[\\s_\\])]|$)")
+var fullEpisodeRangeRegex = regexp.MustCompile("(?i)^(\\d+)\\s*(-\\s*e?|e)\\s*(\\d+)$")
+
+// isEpisodeRange checks that both values are numbers and the range is increasing
+func isEpisodeRange(start string, end string) bool {
+	startNumber, err := strconv.Atoi(start)
+	if err != nil {
+		return false
+	}
+	endNumber, err := strconv.Atoi(end)
+	if err != nil {
+		return false
+	}
+	return startNumber < endNumber
+}
+
+// maxSERangeLength is the largest number of extra episodes in "S01E01-E04" style ranges
+const maxSERangeLength = 4
+
+// isShortEpisodeRange checks that the range is increasing and covers only a few episodes
+func isShortEpisodeRange(start string, end string) bool {
+	startNumber, err := strconv.Atoi(start)
+	if err != nil {
+		return false
+	}
+	endNumber, err := strconv.Atoi(end)
+	if err != nil {
+		return false
+	}
+	return endNumber > startNumber && endNumber-startNumber <= maxSERangeLength
+}
+
+// findEpisodeRangeEnd searches for "<episode>-<end>" in the filename and returns the end, if any
+func findEpisodeRangeEnd(base string, episode string) string {
+	for _, test := range episodeRangeRegex.FindAllStringSubmatch(base, -1) {
+		if test[1] == episode && isEpisodeRange(test[1], test[2]) {
+			return test[2]
+		}
+	}
+	return ""
+}
+
+// splitEpisodeRange splits template values like "05-06", "05-E06" or "05E06" into start and end
+// Both numbers must have the same width in the latter form, so that garbage values like "1E02" are kept
+func splitEpisodeRange(episode string) (string, string) {
+	test := fullEpisodeRangeRegex.FindStringSubmatch(episode)
+	if test == nil || !isEpisodeRange(test[1], test[3]) {
+		return episode, ""
+	}
+	if test[2][0] == '-' || len(test[1]) == len(test[3]) {
+		return test[1], test[3]
+	}
+	return episode, ""
+}
@@ -0,0 +1,18 @@
+package roflmeta
+
+import (
+	"github.com/go-playground/assert/v2"
+	"testing"
+)
+
+func TestSplitEpisodeRange(t *testing.T) {
+	episode, end := splitEpisodeRange("05-06")
+	assert.Equal(t, episode, "05")
+	assert.Equal(t, end, "06")
+	episode, end = splitEpisodeRange("05E06")
+	assert.Equal(t, episode, "05")
+	assert.Equal(t, end, "06")
+	episode, end = splitEpisodeRange("1E02")
+	assert.Equal(t, episode, "1E02")
+	assert.Equal(t, end, "")
+}
@@ -0,0 +1,107 @@
+package roflmeta
+
+// Names of the built-in rules of the single file parser in the order they are tried
+// Use them to place custom rules with WithRuleBefore and WithRuleAfter
+const (
+	RuleSERange          = "SxxExx-yy"
+	RuleSxE              = "SxE"
+	RuleES               = "ExxSxx"
+	RuleSE               = "SxxExx"
+	RuleCJKEpisode       = "第N話"
+	RuleAirDate          = "air date"
+	RuleEp               = "ep N"
+	RuleEpisode          = "episode N"
+	RuleLocalizedEpisode = "localized episode N"
+	RuleDotSpace         = "N. "
+	RuleSeasonWord       = "season word"
+	RuleSeason           = "season N"
+	RuleLocalizedSeason  = "localized season N"
+	RuleCJKSeason        = "第N期"
+	RulePart             = "part"
+	RuleEpisodeRange     = "episode range"
+	RuleBracketNumber    = "bracket number"
+	RuleStartsWithNumber = "single cluster starting with number"
+	RuleNumberWithSpace  = "single cluster with single number"
+	RuleLastResort       = "last resort"
+	RuleSeasonAsEpisode  = "season as episode"
+	RuleBaseName         = "base name"
+)
+
+// RuleTrace describes a single rule attempted by the single file parser
+type RuleTrace struct {
+	Rule string
+	// Match is the matched part of the working string, empty if the rule didn't match
+	Match string
+}
+
+// Explanation describes how ParseSingleEpisodeMetadata arrived at its result
+type Explanation struct {
+	Filename string
+	// Rules are listed in the order they were tried
+	Rules []RuleTrace
+	// Winner is the name of the rule which produced the episode
+	Winner string
+}
+
+// GroupFrequency is the number of distinct non-empty values of a template variable
+type GroupFrequency struct {
+	Group int
+	Value int
+}
+
+// DirExplanation describes how a single directory was processed by ParseMultipleEpisodeMetadata
+type DirExplanation struct {
+	Dir string
+	// Template is the restored filename template, empty if restoration failed
+	Template string
+	// Frequencies are sorted in ascending order, as used to select season and episode groups
+	Frequencies []GroupFrequency
+	// Fallback is true when files of this directory were parsed by the single file parser
+	Fallback bool
+	// Files contains single parser explanations, only filled when Fallback is true or air dates were used
+	Files []Explanation
+}
+
+// MultipleExplanation describes how ParseMultipleEpisodeMetadata arrived at its result
+type MultipleExplanation struct {
+	Dirs []DirExplanation
+	// DirTemplate is the template restored from directory names, empty if it wasn't used
+	DirTemplate string
+}
+
+func (e *Explanation) try(rule string, match string) {
+	if e == nil {
+		return
+	}
+	e.Rules = append(e.Rules, RuleTrace{
+		Rule:  rule,
+		Match: match,
+	})
+}
+
+func (e *Explanation) win(rule string) {
+	if e == nil {
+		return
+	}
+	e.Winner = rule
+}
+
+func (d *DirExplanation) setFrequencies(frequencies []frequency) {
+	if d == nil {
+		return
+	}
+	d.Frequencies = make([]GroupFrequency, 0, len(frequencies))
+	for _, f := range frequencies {
+		d.Frequencies = append(d.Frequencies, GroupFrequency{
+			Group: f.group,
+			Value: f.value,
+		})
+	}
+}
+
+func firstMatch(test []string) string {
+	if test == nil {
+		return ""
+	}
+	return test[0]
+}
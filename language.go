@@ -0,0 +1,110 @@
+package roflmeta
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// LanguageKeywords lists words used for seasons and episodes in filenames of a single language
+// English words are always recognised and don't need to be added, see WithLanguages
+type LanguageKeywords struct {
+	// Language is a language code, e.g. "ru", keywords of a built-in language with the same code are replaced
+	Language string
+	Season   []string
+	Episode  []string
+}
+
+type languageRegexes struct {
+	// e.g. "Сезон 2" and "2 сезон"
+	seasonForward  *regexp.Regexp
+	seasonBackward *regexp.Regexp
+	// e.g. "Серия 5" and "5 серия"
+	episodeForward  *regexp.Regexp
+	episodeBackward *regexp.Regexp
+}
+
+var builtinLanguages = []LanguageKeywords{
+	{Language: "ru", Season: []string{"сезон"}, Episode: []string{"серия", "эпизод", "выпуск"}},
+	{Language: "uk", Season: []string{"сезон"}, Episode: []string{"серія", "епізод", "випуск"}},
+	{Language: "es", Season: []string{"temporada"}, Episode: []string{"capítulo", "capitulo", "cap", "episodio"}},
+	{Language: "pt", Season: []string{"temporada"}, Episode: []string{"episódio", "episodio", "capítulo", "capitulo"}},
+	{Language: "de", Season: []string{"staffel"}, Episode: []string{"folge"}},
+	{Language: "fr", Season: []string{"saison"}, Episode: []string{"épisode"}},
+	{Language: "it", Season: []string{"stagione"}, Episode: []string{"episodio", "puntata"}},
+	{Language: "pl", Season: []string{"sezon"}, Episode: []string{"odcinek"}},
+	{Language: "tr", Season: []string{"sezon"}, Episode: []string{"bölüm"}},
+	{Language: "nl", Season: []string{"seizoen"}, Episode: []string{"aflevering"}},
+}
+
+var builtinLanguageRegexes = compileLanguages(builtinLanguages)
+
+// mergeLanguage replaces keywords of the same language or appends them
+func mergeLanguage(languages []LanguageKeywords, keywords LanguageKeywords) []LanguageKeywords {
+	for i := range languages {
+		if languages[i].Language == keywords.Language {
+			languages[i] = keywords
+			return languages
+		}
+	}
+	return append(languages, keywords)
+}
+
+// keywordAlternation quotes words, longer words go first, so that "capítulo" is preferred over "cap"
+func keywordAlternation(words []string) string {
+	set := make(map[string]struct{}, len(words))
+	for _, word := range words {
+		if word = strings.TrimSpace(word); word != "" {
+			set[regexp.QuoteMeta(strings.ToLower(word))] = struct{}{}
+		}
+	}
+	quoted := make([]string, 0, len(set))
+	for word := range set {
+		quoted = append(quoted, word)
+	}
+	sort.Slice(quoted, func(i, j int) bool {
+		if len(quoted[i]) != len(quoted[j]) {
+			return len(quoted[i]) > len(quoted[j])
+		}
+		return quoted[i] < quoted[j]
+	})
+	return strings.Join(quoted, "|")
+}
+
+func compileKeywordRegexes(words []string) (*regexp.Regexp, *regexp.Regexp) {
+	alternation := keywordAlternation(words)
+	if alternation == "" {
+		return nil, nil
+	}
+	forward := regexp.MustCompile("(?i)(?:^|[^\\p{L}])(?:" + alternation + ")\\.?\\s*(\\d+)")
+	// ordinal suffixes, e.g. "2ª Temporada" or "2-й сезон"
+	backward := regexp.MustCompile("(?i)(?:^|[^\\p{L}\\p{N}])(\\d+)\\s*(?:ª|º|-?(?:й|я|ой|ая))?\\s*(?:" + alternation + ")(?:[^\\p{L}]|$)")
+	return forward, backward
+}
+
+func compileLanguages(languages []LanguageKeywords) languageRegexes {
+	seasonWords := make([]string, 0, len(languages))
+	episodeWords := make([]string, 0, len(languages))
+	for _, language := range languages {
+		seasonWords = append(seasonWords, language.Season...)
+		episodeWords = append(episodeWords, language.Episode...)
+	}
+	result := languageRegexes{}
+	result.seasonForward, result.seasonBackward = compileKeywordRegexes(seasonWords)
+	result.episodeForward, result.episodeBackward = compileKeywordRegexes(episodeWords)
+	return result
+}
+
+// findKeywordNumber returns the number next to a keyword and the matched text
+func findKeywordNumber(s string, forward *regexp.Regexp, backward *regexp.Regexp) (string, string) {
+	if forward == nil {
+		return "", ""
+	}
+	if test := forward.FindStringSubmatch(s); test != nil {
+		return test[1], test[0]
+	}
+	if test := backward.FindStringSubmatch(s); test != nil {
+		return test[1], test[0]
+	}
+	return "", ""
+}
@@ -0,0 +1,21 @@
+package roflmeta
+
+import (
+	"github.com/go-playground/assert/v2"
+	"testing"
+)
+
+func TestLanguageKeywords(t *testing.T) {
+	regexes := builtinLanguageRegexes
+	episode, match := findKeywordNumber("Сезон 2 Серия 5", regexes.episodeForward, regexes.episodeBackward)
+	assert.Equal(t, episode, "5")
+	assert.Equal(t, match, " Серия 5")
+	episode, _ = findKeywordNumber("Show Cap. 12", regexes.episodeForward, regexes.episodeBackward)
+	assert.Equal(t, episode, "12")
+	season, _ := findKeywordNumber("Show 2ª Temporada", regexes.seasonForward, regexes.seasonBackward)
+	assert.Equal(t, season, "2")
+	season, _ = findKeywordNumber("Шоу 3-й сезон", regexes.seasonForward, regexes.seasonBackward)
+	assert.Equal(t, season, "3")
+	_, match = findKeywordNumber("Capitan America 2", regexes.episodeForward, regexes.episodeBackward)
+	assert.Equal(t, match, "")
+}
@@ -0,0 +1,230 @@
+package roflmeta
+
+import (
+	"encoding/json"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// LearnedTemplate is a template restored by the multiple parser along with roles of its variables,
+// see DirOutcome.Template
+// It is meant to be saved as JSON and used to parse files that arrive later exactly like their siblings,
+// e.g. a new episode of a weekly airing series, without restoring the template again
+type LearnedTemplate struct {
+	// Template is the restored template with variables marked with '*', e.g. "Show - *.mkv",
+	// it describes file names without bracket contents, version suffixes and extra whitespace,
+	// directories are included only if they have variables
+	Template string `json:"template"`
+	// SeasonGroup is the variable holding season, counting from 1, 0 if season doesn't change
+	SeasonGroup int `json:"seasonGroup,omitempty"`
+	// EpisodeGroup is the variable holding episode, counting from 1
+	EpisodeGroup int `json:"episodeGroup"`
+	// SeasonIsPart is true if SeasonGroup holds part of a split season, e.g. "Final Season Part 2"
+	SeasonIsPart bool `json:"seasonIsPart,omitempty"`
+	// Title and Season are the same for all files if SeasonGroup is 0, Season is also used if SeasonIsPart is true
+	Title  string `json:"title,omitempty"`
+	Season string `json:"season,omitempty"`
+	// AbsoluteOffset is added to episode to get AbsoluteEpisode, nil if unknown
+	AbsoluteOffset *int `json:"absoluteOffset,omitempty"`
+
+	// regex is compiled once when the template is learned or unmarshalled, so that matching is safe for concurrent use
+	regex *regexp.Regexp
+}
+
+var multipleSpacesRegex = regexp.MustCompile("\\s+")
+
+func newLearnedTemplate(t *template, roles templateRoles, filenames []string) *LearnedTemplate {
+	result := &LearnedTemplate{
+		Template:     normalizeSpaces(generalizeTemplate(t.String())),
+		SeasonGroup:  roles.seasonGroup,
+		EpisodeGroup: roles.episodeGroup,
+	}
+	result.regex = result.compileRegex()
+	if roles.seasonGroup > 0 {
+		result.SeasonIsPart = isPartGroup(filenames[0], t.toRegex(), roles.seasonGroup)
+	}
+	return result
+}
+
+// generalizeTemplate removes constant directories and widens variables over adjacent digits, so that the template
+// doesn't depend on location of the files and the numbers they had, e.g. "/data/Show/Show - 0*.mkv" restored
+// from episodes 01-09 becomes "Show - *.mkv", which matches episode 10 too
+func generalizeTemplate(s string) string {
+	if index := strings.IndexRune(s, '*'); index >= 0 {
+		s = s[strings.LastIndex(s[:index], "/")+1:]
+	}
+	runes := []rune(s)
+	widened := make([]bool, len(runes))
+	for i, r := range runes {
+		if r != '*' {
+			continue
+		}
+		// digits between two variables are kept, otherwise the variables would merge
+		left := i
+		for left > 0 && unicode.IsDigit(runes[left-1]) {
+			left--
+		}
+		if left == 0 || runes[left-1] != '*' {
+			for j := left; j < i; j++ {
+				widened[j] = true
+			}
+		}
+		right := i + 1
+		for right < len(runes) && unicode.IsDigit(runes[right]) {
+			right++
+		}
+		if right == len(runes) || runes[right] != '*' {
+			for j := i + 1; j < right; j++ {
+				widened[j] = true
+			}
+		}
+	}
+	result := make([]rune, 0, len(runes))
+	for i, r := range runes {
+		if !widened[i] {
+			result = append(result, r)
+		}
+	}
+	return string(result)
+}
+
+// learnConstants remembers final values of the first regular file, so that new files get the same ones
+func (t *LearnedTemplate) learnConstants(entries []*fileEntry) {
+	entry := entries[0]
+	for _, e := range entries {
+		if e.kind == KindRegular {
+			entry = e
+			break
+		}
+	}
+	if t.SeasonGroup == 0 || t.SeasonIsPart {
+		t.Season = entry.result.Season
+	}
+	if t.SeasonGroup > 0 {
+		return
+	}
+	t.Title = entry.result.Title
+	if episode, err := strconv.Atoi(entry.result.Episode); err == nil && entry.result.AbsoluteEpisode > 0 {
+		offset := entry.result.AbsoluteEpisode - episode
+		t.AbsoluteOffset = &offset
+	}
+}
+
+// normalizeSpaces collapses whitespace left by removed brackets and trims it around every path component,
+// extensions excluded, e.g. " Show - 05  .mkv" becomes "Show - 05.mkv"
+func normalizeSpaces(name string) string {
+	components := strings.Split(name, "/")
+	for i, component := range components {
+		ext := path.Ext(component)
+		if strings.ContainsAny(ext, " *") {
+			ext = ""
+		}
+		body := multipleSpacesRegex.ReplaceAllLiteralString(strings.TrimSuffix(component, ext), " ")
+		components[i] = strings.TrimSpace(body) + ext
+	}
+	return strings.Join(components, "/")
+}
+
+// UnmarshalJSON implements json.Unmarshaler, the template is compiled right away
+func (t *LearnedTemplate) UnmarshalJSON(data []byte) error {
+	type plain LearnedTemplate
+	if err := json.Unmarshal(data, (*plain)(t)); err != nil {
+		return err
+	}
+	t.regex = t.compileRegex()
+	return nil
+}
+
+// compileRegex compiles the template, templates saved before whitespace was normalized are normalized too
+func (t *LearnedTemplate) compileRegex() *regexp.Regexp {
+	restored := newTemplate(normalizeSpaces(t.Template))
+	return restored.toRegex()
+}
+
+// getRegex returns the compiled template, templates built by hand are compiled on every call
+func (t *LearnedTemplate) getRegex() *regexp.Regexp {
+	if t.regex != nil {
+		return t.regex
+	}
+	return t.compileRegex()
+}
+
+// Match parses a single filename, returns false if the filename doesn't match the template or isn't a video
+func (t *LearnedTemplate) Match(filename string) (EpisodeMetadata, bool) {
+	return defaultParser.matchLearnedTemplate(t, filename)
+}
+
+// Parse parses filenames using the template, see ParseWithLearnedTemplate
+func (t *LearnedTemplate) Parse(filenames []string) ([]EpisodeMetadata, []string) {
+	return defaultParser.ParseWithLearnedTemplate(t, filenames)
+}
+
+// ParseWithLearnedTemplate parses filenames using a template learned by the multiple parser
+// Files that don't match the template are parsed by the single file parser and returned as the second value
+// Non-video files are ignored like in other functions
+func (p *Parser) ParseWithLearnedTemplate(t *LearnedTemplate, filenames []string) ([]EpisodeMetadata, []string) {
+	return p.parseWithMatcher(filenames, func(filename string) (EpisodeMetadata, bool) {
+		return p.matchLearnedTemplate(t, filename)
+	})
+}
+
+func (p *Parser) matchLearnedTemplate(t *LearnedTemplate, filename string) (EpisodeMetadata, bool) {
+	if !p.isVideo(filename) {
+		return EpisodeMetadata{}, false
+	}
+	// the same cleaning as in parseMultipleEpisodeMetadata
+	cleanedFileName := filename
+	if p.stripBrackets {
+		cleanedFileName = preCleanFileName(filename)
+	}
+	_, cleanedFileName = parseVersion(normalizeCJK(cleanedFileName))
+	// the template describes only the file name and directories with variables
+	name := normalizeSpaces(lastPathComponents(filepath.ToSlash(cleanedFileName), strings.Count(t.Template, "/")+1))
+	test := t.getRegex().FindStringSubmatch(name)
+	if test == nil || t.EpisodeGroup >= len(test) || t.SeasonGroup >= len(test) {
+		return EpisodeMetadata{}, false
+	}
+
+	result := EpisodeMetadata{
+		Title:      t.Title,
+		Season:     t.Season,
+		Confidence: confidenceTemplateEpisodes,
+	}
+	result.Episode, result.EpisodeEnd = splitEpisodeRange(postCleanData(test[t.EpisodeGroup]))
+	if result.Episode == "" {
+		return EpisodeMetadata{}, false
+	}
+	if t.SeasonGroup > 0 {
+		result.Title = p.parseSingle(cleanedFileName, nil).Title
+		result.Confidence = confidenceTemplateSeasons
+		if t.SeasonIsPart {
+			result.Part = templatePart(test[t.SeasonGroup])
+		} else {
+			result.Season = postCleanData(test[t.SeasonGroup])
+		}
+	}
+
+	base := filepath.Base(filename)
+	base = strings.TrimSuffix(base, filepath.Ext(base))
+	if result.Part == 0 {
+		result.Part, _ = parsePart(delimiterRegex.ReplaceAllLiteralString(base, " "))
+	}
+	applyDirInfo(&result, p.parseDirHierarchy(filename))
+	if kind := detectContentKind(base); kind != KindRegular {
+		result.Kind = kind
+	}
+	applySpecialSeason(&result)
+	if episode, err := strconv.Atoi(result.Episode); err == nil && t.AbsoluteOffset != nil && result.Kind == KindRegular {
+		result.AbsoluteEpisode = *t.AbsoluteOffset + episode
+	}
+	result.Interstitial = isInterstitial(base, result.Episode)
+	result.Version, _ = parseVersion(base)
+	result.AirDate, _, _ = parseAirDate(base)
+	result.Year = releaseYear(base, result)
+	result.Release = parseReleaseInfo(base)
+	return result, true
+}
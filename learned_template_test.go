@@ -0,0 +1,124 @@
+package roflmeta
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-playground/assert/v2"
+	"sync"
+	"testing"
+)
+
+func weeklyFilenames(count int) []string {
+	result := make([]string, 0, count)
+	for i := 1; i <= count; i++ {
+		result = append(result, fmt.Sprintf("Frieren/[SubsPlease] Sousou no Frieren S2 - %02d (1080p) [ABCD%04d].mkv", i, i))
+	}
+	return result
+}
+
+func learnTemplate(t *testing.T, filenames []string) *LearnedTemplate {
+	_, outcomes, err := ParseMultipleEpisodeMetadataWithOutcomes(filenames)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(outcomes), 1)
+	assert.NotEqual(t, outcomes[0].Template, nil)
+
+	data, err := json.Marshal(outcomes[0].Template)
+	assert.Equal(t, err, nil)
+	var learned LearnedTemplate
+	assert.Equal(t, json.Unmarshal(data, &learned), nil)
+	return &learned
+}
+
+func TestLearnedTemplateWeekly(t *testing.T) {
+	learned := learnTemplate(t, weeklyFilenames(11))
+	assert.Equal(t, learned.SeasonGroup, 0)
+
+	expected := ParseMultipleEpisodeMetadata(weeklyFilenames(12))[11]
+	newFile := weeklyFilenames(12)[11]
+	metadata, ok := learned.Match(newFile)
+	assert.Equal(t, ok, true)
+	assert.Equal(t, metadata, expected)
+	assert.Equal(t, metadata.Episode, "12")
+	assert.Equal(t, metadata.AbsoluteEpisode, 12)
+
+	results, unmatched := learned.Parse([]string{newFile, "Frieren/Sample.mkv", "Frieren/Sample.txt"})
+	assert.Equal(t, results[0], expected)
+	assert.Equal(t, unmatched, []string{"Frieren/Sample.mkv"})
+	assert.Equal(t, results[2], EpisodeMetadata{})
+}
+
+func TestLearnedTemplateSeasons(t *testing.T) {
+	filenames := []string{
+		"Show/Show S01E01.mkv",
+		"Show/Show S01E02.mkv",
+		"Show/Show S01E13.mkv",
+		"Show/Show S02E04.mkv",
+		"Show/Show S02E05.mkv",
+	}
+	learned := learnTemplate(t, filenames)
+	assert.NotEqual(t, learned.SeasonGroup, 0)
+	assert.Equal(t, learned.Template, "Show S*E*.mkv")
+	metadata, ok := learned.Match("Show/Show S03E07.mkv")
+	assert.Equal(t, ok, true)
+	assert.Equal(t, metadata.Title, "Show")
+	assert.Equal(t, metadata.Season, "03")
+	assert.Equal(t, metadata.Episode, "07")
+	metadata, ok = learned.Match("/mnt/library/Show/Show S10E01.mkv")
+	assert.Equal(t, ok, true)
+	assert.Equal(t, metadata.Season, "10")
+	_, ok = learned.Match("Show/Other S03E07.mkv")
+	assert.Equal(t, ok, false)
+}
+
+func TestLearnedTemplateNewDigits(t *testing.T) {
+	learned := learnTemplate(t, genInput("/data/Show/Show - %02d.mkv", 1, 9))
+	assert.Equal(t, learned.Template, "Show - *.mkv")
+	metadata, ok := learned.Match("/data/Show/Show - 10.mkv")
+	assert.Equal(t, ok, true)
+	assert.Equal(t, metadata.Episode, "10")
+	assert.Equal(t, metadata.AbsoluteEpisode, 10)
+	// the library was moved
+	metadata, ok = learned.Match("/mnt/anime/Show/Show - 11.mkv")
+	assert.Equal(t, ok, true)
+	assert.Equal(t, metadata.Episode, "11")
+}
+
+func TestGeneralizeTemplate(t *testing.T) {
+	assert.Equal(t, generalizeTemplate("/data/Show/Show - 0*.mkv"), "Show - *.mkv")
+	assert.Equal(t, generalizeTemplate("Show S0*E1*.mkv"), "Show S*E*.mkv")
+	assert.Equal(t, generalizeTemplate("Show Season */Show - 1*.mkv"), "Show Season */Show - *.mkv")
+	assert.Equal(t, generalizeTemplate("Show *01*.mkv"), "Show *01*.mkv")
+}
+
+func TestLearnedTemplateSpaces(t *testing.T) {
+	learned := learnTemplate(t, weeklyFilenames(11))
+	assert.Equal(t, learned.Template, "Sousou no Frieren S2 - *.mkv")
+	// new file without checksum and resolution tags
+	metadata, ok := learned.Match("Frieren/[SubsPlease] Sousou no Frieren S2 - 12.mkv")
+	assert.Equal(t, ok, true)
+	assert.Equal(t, metadata.Episode, "12")
+	// templates saved before normalization still match
+	metadata, ok = (&LearnedTemplate{Template: " Sousou no Frieren S2 - *  .mkv", EpisodeGroup: 1}).Match("Frieren/Sousou no Frieren S2 - 13.mkv")
+	assert.Equal(t, ok, true)
+	assert.Equal(t, metadata.Episode, "13")
+}
+
+func TestLearnedTemplateConcurrent(t *testing.T) {
+	learned := learnTemplate(t, weeklyFilenames(11))
+	var wg sync.WaitGroup
+	for i := 12; i < 20; i++ {
+		wg.Add(1)
+		go func(filename string) {
+			defer wg.Done()
+			_, ok := learned.Match(filename)
+			assert.Equal(t, ok, true)
+		}(weeklyFilenames(i)[i-1])
+	}
+	wg.Wait()
+}
+
+func TestNormalizeSpaces(t *testing.T) {
+	assert.Equal(t, normalizeSpaces(" Show - *  .mkv"), "Show - *.mkv")
+	assert.Equal(t, normalizeSpaces("Show  Season */ Show -  05 .MKV"), "Show Season */Show - 05.MKV")
+	assert.Equal(t, normalizeSpaces("Show.S01E*"), "Show.S01E*")
+}
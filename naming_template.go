@@ -0,0 +1,211 @@
+package roflmeta
+
+import (
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var placeholderRegex = regexp.MustCompile("\\{([a-z]+)(?::(number|text))?\\}")
+
+const numberVarRegex = "(\\d+(?:\\.\\d+)?)"
+
+// placeholderNumeric lists known placeholders and whether they are numeric by default, "any" is matched and ignored
+var placeholderNumeric = map[string]bool{
+	"title":      false,
+	"season":     true,
+	"episode":    true,
+	"part":       true,
+	"year":       true,
+	"group":      false,
+	"resolution": false,
+	"any":        false,
+}
+
+// NamingTemplate is a known filename template with named placeholders, see CompileNamingTemplate
+type NamingTemplate struct {
+	pattern  string
+	template template
+	regex    *regexp.Regexp
+	names    []string
+	// components is the number of path components the template describes, e.g. 3 for "{title}/Season {season}/{episode}.mkv"
+	components int
+	// ext is the lower case extension, it is empty if the template doesn't describe it, e.g. "{title} - {episode}"
+	ext string
+}
+
+// CompileNamingTemplate compiles a template like "[Group] {title} - {episode} [{resolution}].mkv"
+// or "{title}/Season {season}/{title} S{season}E{episode}.mkv"
+//
+// Placeholders are {title}, {season}, {episode}, {part}, {year}, {group}, {resolution} and {any} for ignored text
+// {season}, {episode}, {part} and {year} match numbers, others match any text, the type can be set explicitly,
+// e.g. {episode:text} for episodes like "OVA" or {title:number}
+// The same placeholder may be used multiple times, the last non-empty value wins
+// Templates with "/" describe the last directories of the path, templates without extension match any extension,
+// extension is compared ignoring case, e.g. ".mkv" matches "Show - 05.MKV"
+// Literal '*' is not supported, as it marks variables of restored templates
+//
+// Returned error is ErrInvalidNamingTemplate if the template has unknown placeholders, '*' or lacks {episode}
+func CompileNamingTemplate(pattern string) (*NamingTemplate, error) {
+	if strings.ContainsRune(pattern, '*') {
+		return nil, ErrInvalidNamingTemplate
+	}
+	result := &NamingTemplate{
+		pattern:    pattern,
+		components: strings.Count(pattern, "/") + 1,
+	}
+	runes := make([]rune, 0, len(pattern))
+	varRegexes := make([]string, 0)
+	hasEpisode := false
+	last := 0
+	for _, loc := range placeholderRegex.FindAllStringSubmatchIndex(pattern, -1) {
+		literal := pattern[last:loc[0]]
+		if strings.ContainsAny(literal, "{}") {
+			return nil, ErrInvalidNamingTemplate
+		}
+		name := pattern[loc[2]:loc[3]]
+		numeric, ok := placeholderNumeric[name]
+		if !ok {
+			return nil, ErrInvalidNamingTemplate
+		}
+		if loc[4] >= 0 {
+			numeric = pattern[loc[4]:loc[5]] == "number"
+		}
+		if numeric {
+			varRegexes = append(varRegexes, numberVarRegex)
+		} else {
+			varRegexes = append(varRegexes, textVarRegex)
+		}
+		hasEpisode = hasEpisode || name == "episode"
+		runes = append(runes, []rune(literal)...)
+		runes = append(runes, '*')
+		result.names = append(result.names, name)
+		last = loc[1]
+	}
+	literal := pattern[last:]
+	if strings.ContainsAny(literal, "{}") || !hasEpisode {
+		return nil, ErrInvalidNamingTemplate
+	}
+	// extension is compared separately, ignoring case
+	if ext := path.Ext(literal); ext != "" && !strings.ContainsAny(ext, " /") {
+		result.ext = strings.ToLower(ext)
+		literal = strings.TrimSuffix(literal, ext)
+	}
+	runes = append(runes, []rune(literal)...)
+	result.template = template{runes: runes, varRegexes: varRegexes}
+	result.regex = result.template.toRegex()
+	return result, nil
+}
+
+// String returns the template as it was compiled
+func (t *NamingTemplate) String() string {
+	return t.pattern
+}
+
+// Match parses a single filename, returns false if the filename doesn't match the template or isn't a video
+func (t *NamingTemplate) Match(filename string) (EpisodeMetadata, bool) {
+	return defaultParser.matchNamingTemplate(t, filename)
+}
+
+// Parse parses filenames using the template, see ParseWithNamingTemplate
+func (t *NamingTemplate) Parse(filenames []string) ([]EpisodeMetadata, []string) {
+	return defaultParser.ParseWithNamingTemplate(t, filenames)
+}
+
+// ParseWithNamingTemplate parses filenames using a known template
+// Files that don't match the template are parsed by the single file parser and returned as the second value
+// Non-video files are ignored like in other functions
+func (p *Parser) ParseWithNamingTemplate(t *NamingTemplate, filenames []string) ([]EpisodeMetadata, []string) {
+	return p.parseWithMatcher(filenames, func(filename string) (EpisodeMetadata, bool) {
+		return p.matchNamingTemplate(t, filename)
+	})
+}
+
+// parseWithMatcher parses filenames with match, videos that don't match are parsed by the single file parser
+// and returned as the second value
+func (p *Parser) parseWithMatcher(filenames []string, match func(filename string) (EpisodeMetadata, bool)) ([]EpisodeMetadata, []string) {
+	result := make([]EpisodeMetadata, 0, len(filenames))
+	unmatched := make([]string, 0)
+	for _, name := range filenames {
+		metadata, ok := match(name)
+		if !ok && p.isVideo(name) {
+			metadata = p.ParseSingle(name)
+			unmatched = append(unmatched, name)
+		}
+		result = append(result, metadata)
+	}
+	return result, unmatched
+}
+
+// lastPathComponents returns the last count components of the slash separated path
+func lastPathComponents(name string, count int) string {
+	index := len(name)
+	for i := 0; i < count; i++ {
+		index = strings.LastIndex(name[:index], "/")
+		if index < 0 {
+			return name
+		}
+	}
+	return name[index+1:]
+}
+
+func (p *Parser) matchNamingTemplate(t *NamingTemplate, filename string) (EpisodeMetadata, bool) {
+	if !p.isVideo(filename) {
+		return EpisodeMetadata{}, false
+	}
+	name := lastPathComponents(filepath.ToSlash(filename), t.components)
+	ext := path.Ext(name)
+	if t.ext != "" && strings.ToLower(ext) != t.ext {
+		return EpisodeMetadata{}, false
+	}
+	name = strings.TrimSuffix(name, ext)
+	// version suffix is not a part of the episode, e.g. "05v2"
+	name = versionSuffixRegex.ReplaceAllString(name, "${1}${3}")
+	test := t.regex.FindStringSubmatch(name)
+	if test == nil {
+		return EpisodeMetadata{}, false
+	}
+	values := make(map[string]string, len(t.names))
+	for i, placeholder := range t.names {
+		if value := postCleanData(test[i+1]); value != "" {
+			values[placeholder] = value
+		}
+	}
+	if values["episode"] == "" {
+		return EpisodeMetadata{}, false
+	}
+
+	base := filepath.Base(filename)
+	base = strings.TrimSuffix(base, filepath.Ext(base))
+	result := EpisodeMetadata{
+		Title:      values["title"],
+		Season:     values["season"],
+		Kind:       detectContentKind(base),
+		Confidence: confidenceNamingTemplate,
+	}
+	applySpecialSeason(&result)
+	result.Episode, result.EpisodeEnd = splitEpisodeRange(values["episode"])
+	result.Interstitial = isInterstitial(base, result.Episode)
+	result.Version, _ = parseVersion(base)
+	if part, err := strconv.Atoi(values["part"]); err == nil {
+		result.Part = part
+	} else {
+		result.Part, _ = parsePart(delimiterRegex.ReplaceAllLiteralString(base, " "))
+	}
+	result.AirDate, _, _ = parseAirDate(base)
+	if year, err := strconv.Atoi(values["year"]); err == nil {
+		result.Year = year
+	} else {
+		result.Year = releaseYear(base, result)
+	}
+	result.Release = parseReleaseInfo(base)
+	if group := values["group"]; group != "" {
+		result.Release.Group = group
+	}
+	if resolution := values["resolution"]; resolution != "" {
+		result.Release.Resolution = resolution
+	}
+	return result, true
+}
@@ -0,0 +1,73 @@
+package roflmeta
+
+import (
+	"github.com/go-playground/assert/v2"
+	"testing"
+)
+
+func TestCompileNamingTemplate(t *testing.T) {
+	_, err := CompileNamingTemplate("{title} - {episode}.mkv")
+	assert.Equal(t, err, nil)
+	_, err = CompileNamingTemplate("{title} - {chapter}.mkv")
+	assert.Equal(t, err, ErrInvalidNamingTemplate)
+	_, err = CompileNamingTemplate("{title} - {season}.mkv")
+	assert.Equal(t, err, ErrInvalidNamingTemplate)
+	_, err = CompileNamingTemplate("{title} - {episode:date}.mkv")
+	assert.Equal(t, err, ErrInvalidNamingTemplate)
+	_, err = CompileNamingTemplate("{title} - * {episode}.mkv")
+	assert.Equal(t, err, ErrInvalidNamingTemplate)
+}
+
+func TestNamingTemplateGroup(t *testing.T) {
+	tmpl, err := CompileNamingTemplate("[Group] {title} - {episode} [{resolution}].mkv")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, tmpl.String(), "[Group] {title} - {episode} [{resolution}].mkv")
+	metadata, ok := tmpl.Match("/downloads/[Group] Sousou no Frieren - 05v2 [1080p].mkv")
+	assert.Equal(t, ok, true)
+	assert.Equal(t, metadata.Title, "Sousou no Frieren")
+	assert.Equal(t, metadata.Episode, "05")
+	assert.Equal(t, metadata.Version, 2)
+	assert.Equal(t, metadata.Release.Resolution, "1080p")
+	assert.Equal(t, metadata.Release.Group, "Group")
+	assert.Equal(t, metadata.Confidence, 1.0)
+	_, ok = tmpl.Match("[Group] Sousou no Frieren - Recap [1080p].mkv")
+	assert.Equal(t, ok, false)
+	metadata, ok = tmpl.Match("[Group] Sousou no Frieren - 06 [1080p].MKV")
+	assert.Equal(t, ok, true)
+	assert.Equal(t, metadata.Episode, "06")
+	_, ok = tmpl.Match("[Group] Sousou no Frieren - 06 [1080p].mp4")
+	assert.Equal(t, ok, false)
+}
+
+func TestNamingTemplatePath(t *testing.T) {
+	tmpl, err := CompileNamingTemplate("{title}/Season {season}/{title} S{season}E{episode}")
+	assert.Equal(t, err, nil)
+	results, unmatched := tmpl.Parse([]string{
+		"/tv/Dr Stone/Season 2/Dr Stone S02E01.mkv",
+		"/tv/Dr Stone/Season 2/Dr Stone S02E02.mp4",
+		"/tv/Dr Stone/Season 2/Dr Stone S02E02.srt",
+		"/tv/Dr Stone/Season 2/Dr Stone - 03.mkv",
+	})
+	assert.Equal(t, unmatched, []string{"/tv/Dr Stone/Season 2/Dr Stone - 03.mkv"})
+	assert.Equal(t, results[0].Title, "Dr Stone")
+	assert.Equal(t, results[0].Season, "02")
+	assert.Equal(t, results[0].Episode, "01")
+	assert.Equal(t, results[1].Episode, "02")
+	assert.Equal(t, results[2], EpisodeMetadata{})
+	// unmatched files are parsed by the single file parser
+	assert.Equal(t, results[3].Season, "2")
+	assert.Equal(t, results[3].Episode, "03")
+}
+
+func TestNamingTemplateTypes(t *testing.T) {
+	tmpl, _ := CompileNamingTemplate("{title} - {episode}")
+	_, ok := tmpl.Match("Show - OVA.mkv")
+	assert.Equal(t, ok, false)
+	tmpl, _ = CompileNamingTemplate("{title} - {episode:text}")
+	metadata, ok := tmpl.Match("Show - OVA.mkv")
+	assert.Equal(t, ok, true)
+	assert.Equal(t, metadata.Episode, "OVA")
+	metadata, _ = tmpl.Match("Show - 01-02.mkv")
+	assert.Equal(t, metadata.Episode, "01")
+	assert.Equal(t, metadata.EpisodeEnd, "02")
+}
@@ -0,0 +1,144 @@
+package roflmeta
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+const defaultMaxSeason = 99
+
+// Parser holds configuration of the single and multiple file parsers
+// Zero value is not usable, create parsers with NewParser
+// Package-level functions use a Parser with default options
+type Parser struct {
+	videoExtensions map[string]struct{}
+	languageTables  []LanguageKeywords
+	// languages is nil if the parser uses built-in tables
+	languages     *languageRegexes
+	stripBrackets bool
+	maxSeason     int
+	rules         []Rule
+}
+
+// Option configures a Parser, see NewParser
+type Option func(p *Parser)
+
+// WithVideoExtensions treats files with given extensions as videos in addition to the built-in ones,
+// e.g. WithVideoExtensions(".ts", "m2ts")
+func WithVideoExtensions(extensions ...string) Option {
+	return func(p *Parser) {
+		for _, ext := range extensions {
+			ext = strings.ToLower(strings.TrimSpace(ext))
+			if ext == "" {
+				continue
+			}
+			if !strings.HasPrefix(ext, ".") {
+				ext = "." + ext
+			}
+			p.videoExtensions[ext] = struct{}{}
+		}
+	}
+}
+
+// WithLanguages adds keyword tables to the built-in ones, tables with the same Language replace the built-in ones
+func WithLanguages(keywords ...LanguageKeywords) Option {
+	return func(p *Parser) {
+		if p.languageTables == nil {
+			p.languageTables = append([]LanguageKeywords{}, builtinLanguages...)
+		}
+		for _, k := range keywords {
+			p.languageTables = mergeLanguage(p.languageTables, k)
+		}
+	}
+}
+
+// WithBracketStripping sets whether bracket contents are removed before searching for episodes, true by default
+// Disable it if brackets contain meaningful data rather than release tags
+// Episodes in brackets like "Show [05].mkv" are found either way
+func WithBracketStripping(strip bool) Option {
+	return func(p *Parser) {
+		p.stripBrackets = strip
+	}
+}
+
+// WithMaxSeason sets the largest number accepted as a season in formats like "2x05", 99 by default
+// Larger numbers are not seasons, e.g. "1920x1080"
+func WithMaxSeason(maxSeason int) Option {
+	return func(p *Parser) {
+		p.maxSeason = maxSeason
+	}
+}
+
+// WithRule adds a custom rule to the single file parser, such rules are tried in the order they were added
+// and before the built-in ones
+func WithRule(rule Rule) Option {
+	return func(p *Parser) {
+		index := 0
+		for index < len(p.rules) {
+			if _, ok := p.rules[index].(builtinRule); ok {
+				break
+			}
+			index++
+		}
+		p.rules = insertRule(p.rules, index, rule)
+	}
+}
+
+// WithRuleBefore adds a custom rule right before the rule with given name, e.g. RuleEp
+// The rule is added after all rules if there is no rule with such name
+func WithRuleBefore(name string, rule Rule) Option {
+	return func(p *Parser) {
+		index := findRule(p.rules, name)
+		if index < 0 {
+			index = len(p.rules)
+		}
+		p.rules = insertRule(p.rules, index, rule)
+	}
+}
+
+// WithRuleAfter adds a custom rule right after the rule with given name, e.g. RuleSE
+// The rule is added after all rules if there is no rule with such name
+func WithRuleAfter(name string, rule Rule) Option {
+	return func(p *Parser) {
+		index := findRule(p.rules, name)
+		if index < 0 {
+			index = len(p.rules) - 1
+		}
+		p.rules = insertRule(p.rules, index+1, rule)
+	}
+}
+
+// NewParser creates a Parser, options are applied in order
+func NewParser(options ...Option) *Parser {
+	p := &Parser{
+		videoExtensions: make(map[string]struct{}),
+		stripBrackets:   true,
+		maxSeason:       defaultMaxSeason,
+		rules:           builtinRules(),
+	}
+	for _, option := range options {
+		option(p)
+	}
+	if p.languageTables != nil {
+		languages := compileLanguages(p.languageTables)
+		p.languages = &languages
+	}
+	return p
+}
+
+var defaultParser = NewParser()
+
+func (p *Parser) isVideo(name string) bool {
+	if isVideo(name) {
+		return true
+	}
+	_, ok := p.videoExtensions[strings.ToLower(filepath.Ext(name))]
+	return ok
+}
+
+func (p *Parser) languageRegexes() languageRegexes {
+	if p.languages != nil {
+		return *p.languages
+	}
+	return builtinLanguageRegexes
+}
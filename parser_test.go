@@ -0,0 +1,129 @@
+package roflmeta
+
+import (
+	"github.com/go-playground/assert/v2"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+type hashRule struct{}
+
+var hashRuleRegex = regexp.MustCompile("#(\\d+)")
+
+func (hashRule) Name() string {
+	return "#N"
+}
+
+func (hashRule) Apply(state *RuleState) bool {
+	test := hashRuleRegex.FindStringSubmatch(state.Working)
+	if test == nil {
+		return false
+	}
+	state.Result.Episode = test[1]
+	state.Result.Confidence = 1
+	return true
+}
+
+func TestParserVideoExtensions(t *testing.T) {
+	assert.Equal(t, ParseSingleEpisodeMetadata("Show - 05.ts"), EpisodeMetadata{})
+	p := NewParser(WithVideoExtensions("TS", ".m2ts"))
+	assert.Equal(t, p.ParseSingle("Show - 05.ts").Episode, "05")
+	assert.Equal(t, p.ParseSingle("Show - 06.m2ts").Episode, "06")
+	assert.Equal(t, p.ParseSingle("Show - 07.mkv").Episode, "07")
+	results := p.ParseMultiple([]string{"Show - 05.ts", "Show - 12.ts", "Show - 12.srt"})
+	assert.Equal(t, results[1].Episode, "12")
+	assert.Equal(t, results[2], EpisodeMetadata{})
+}
+
+func TestParserMaxSeason(t *testing.T) {
+	assert.Equal(t, ParseSingleEpisodeMetadata("Show 150x05.mkv").Season, "")
+	metadata := NewParser(WithMaxSeason(200)).ParseSingle("Show 150x05.mkv")
+	assert.Equal(t, metadata.Season, "150")
+	assert.Equal(t, metadata.Episode, "05")
+}
+
+func TestParserBracketStripping(t *testing.T) {
+	assert.Equal(t, ParseSingleEpisodeMetadata("Show [05].mkv").Episode, "05")
+	// bracketed text stays in the title, but the episode is still found
+	p := NewParser(WithBracketStripping(false))
+	metadata := p.ParseSingle("Show [Director's Cut] - 05.mkv")
+	assert.Equal(t, metadata.Title, "Show [Director's Cut]")
+	assert.Equal(t, metadata.Episode, "05")
+	metadata = p.ParseSingle("Show (2011) - 05.mkv")
+	assert.Equal(t, metadata.Title, "Show")
+	assert.Equal(t, metadata.Episode, "05")
+	assert.Equal(t, metadata.Year, 2011)
+	metadata = p.ParseSingle("Show [Director's Cut] [05].mkv")
+	assert.Equal(t, metadata.Title, "Show [Director's Cut]")
+	assert.Equal(t, metadata.Episode, "05")
+}
+
+func TestParserLanguages(t *testing.T) {
+	p := NewParser(WithLanguages(LanguageKeywords{Language: "sv", Season: []string{"säsong"}, Episode: []string{"avsnitt"}}))
+	metadata := p.ParseSingle("Bron Säsong 2 Avsnitt 7.mkv")
+	assert.Equal(t, metadata.Season, "2")
+	assert.Equal(t, metadata.Episode, "7")
+	// built-in languages are still there
+	assert.Equal(t, p.ParseSingle("Шоу Сезон 2 Серия 5.mkv").Episode, "5")
+	// other parsers are not affected
+	assert.Equal(t, ParseSingleEpisodeMetadata("Bron Säsong 2 Avsnitt 7.mkv").Season, "")
+}
+
+func TestParserRule(t *testing.T) {
+	p := NewParser(WithRule(hashRule{}))
+	metadata, explanation := p.ParseSingleExplained("Show #12 - The Return.mkv")
+	assert.Equal(t, metadata.Episode, "12")
+	assert.Equal(t, explanation.Winner, "#N")
+	// unclaimed filenames are parsed by the built-in rules
+	assert.Equal(t, p.ParseSingle("Show S01E05.mkv").Episode, "05")
+}
+
+type houseSeasonRule struct{}
+
+var houseSeasonRegex = regexp.MustCompile("(?i)(?:^|\\s)s(\\d+)(?:\\s|$)")
+
+func (houseSeasonRule) Name() string {
+	return "house season"
+}
+
+func (houseSeasonRule) Apply(state *RuleState) bool {
+	if test := houseSeasonRegex.FindStringSubmatch(state.Working); test != nil {
+		state.Result.Season = test[1]
+		state.Working = strings.Replace(state.Working, test[0], "  ", 1)
+	}
+	return false
+}
+
+func TestParserRuleBefore(t *testing.T) {
+	assert.Equal(t, ParseSingleEpisodeMetadata("SHOW_s2_ep05_FINAL.mkv").Season, "")
+	p := NewParser(WithRuleBefore(RuleEp, houseSeasonRule{}))
+	names := p.RuleNames()
+	assert.Equal(t, names[6], "house season")
+	assert.Equal(t, names[7], RuleEp)
+	metadata := p.ParseSingle("SHOW_s2_ep05_FINAL.mkv")
+	assert.Equal(t, metadata.Title, "SHOW")
+	assert.Equal(t, metadata.Season, "2")
+	assert.Equal(t, metadata.Episode, "05")
+}
+
+func TestParserRuleAfter(t *testing.T) {
+	p := NewParser(WithRuleAfter(RuleBaseName, hashRule{}), WithRule(houseSeasonRule{}), WithRuleAfter("missing", hashRule{}))
+	names := p.RuleNames()
+	assert.Equal(t, names[0], "house season")
+	assert.Equal(t, names[len(names)-2], "#N")
+	assert.Equal(t, names[len(names)-1], "#N")
+	assert.Equal(t, len(names), len(defaultParser.RuleNames())+3)
+	// rules after the built-in ones may override their result
+	assert.Equal(t, p.ParseSingle("Show - 05 #12.mkv").Episode, "12")
+	assert.Equal(t, p.ParseSingle("Show - 05.mkv").Episode, "05")
+}
+
+func TestParserRuleAfterClusters(t *testing.T) {
+	p := NewParser(WithRuleAfter(RuleStartsWithNumber, houseSeasonRule{}))
+	metadata := p.ParseSingle("Show s2 - Finale - 07.mkv")
+	assert.Equal(t, metadata.Episode, "07")
+	assert.Equal(t, metadata.Season, "2")
+	// later built-in rules see changes of the working string
+	assert.Equal(t, metadata.Title, "Show")
+}
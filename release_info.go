@@ -0,0 +1,166 @@
+package roflmeta
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ReleaseInfo describes the release the file belongs to, fields are empty if filename lacks information
+type ReleaseInfo struct {
+	// Group is the release group, e.g. "Judas" for "[Judas] Show - 01" or "NTb" for "Show.S01E01.1080p.WEB-DL.x264-NTb"
+	Group string
+	// Resolution is the vertical resolution followed by p, e.g. "1080p", "1920x1080" is reported as "1080p"
+	Resolution string
+	// VideoCodec is one of "H.264", "H.265", "AV1", "VP9", "XviD", "DivX"
+	VideoCodec string
+	// AudioCodec is one of "AAC", "E-AC3", "AC3", "FLAC", "Opus", "MP3", "DTS", "TrueHD"
+	AudioCodec string
+	// Source is one of "BluRay", "WEB-DL", "WEBRip", "HDTV", "DVD"
+	Source    string
+	DualAudio bool
+	TenBit    bool
+	// Checksum is the uppercase CRC32 tag, e.g. "28B342E5" for "[SubsPlease] Show - 01 (1080p) [28B342E5]"
+	// See VerifyChecksum and VerifyFileChecksum
+	Checksum string
+}
+
+type releaseTag struct {
+	value string
+	regex *regexp.Regexp
+}
+
+// tag boundaries are anything but letters and digits, so that "[1080p]", "_x265_" and "(480p DVDRip - DUAL Audio)" all match
+func newReleaseTagRegex(pattern string) *regexp.Regexp {
+	return regexp.MustCompile("(?i)(?:^|[^\\p{L}\\p{N}])(?:" + pattern + ")(?:[^\\p{L}\\p{N}]|$)")
+}
+
+// order matters, the first matching tag wins
+var videoCodecTags = []releaseTag{
+	{"H.265", newReleaseTagRegex("[xh]\\.?265|hevc")},
+	{"H.264", newReleaseTagRegex("[xh]\\.?264|avc")},
+	{"AV1", newReleaseTagRegex("av1")},
+	{"VP9", newReleaseTagRegex("vp9")},
+	{"XviD", newReleaseTagRegex("xvid")},
+	{"DivX", newReleaseTagRegex("divx")},
+}
+
+var audioCodecTags = []releaseTag{
+	{"E-AC3", newReleaseTagRegex("e-?ac-?3|ddp(?:\\d\\.\\d)?|dd\\+")},
+	{"AC3", newReleaseTagRegex("ac-?3|dd\\d\\.\\d")},
+	{"AAC", newReleaseTagRegex("aac(?:\\d\\.\\d)?")},
+	{"FLAC", newReleaseTagRegex("flac")},
+	{"Opus", newReleaseTagRegex("opus")},
+	{"MP3", newReleaseTagRegex("mp3")},
+	{"TrueHD", newReleaseTagRegex("truehd")},
+	{"DTS", newReleaseTagRegex("dts(?:-?hd)?(?:-?ma)?")},
+}
+
+// BD is often glued to resolution, e.g. "BD1080p"
+var sourceTags = []releaseTag{
+	{"BluRay", newReleaseTagRegex("blu-?ray(?:rip)?|bd(?:rip|remux)?(?:\\d{3,4}p)?|bdmv")},
+	{"WEB-DL", newReleaseTagRegex("web-?dl")},
+	{"WEBRip", newReleaseTagRegex("web-?rip")},
+	{"HDTV", newReleaseTagRegex("hdtv(?:rip)?|tvrip")},
+	{"DVD", newReleaseTagRegex("dvd(?:rip|5|9)?")},
+}
+
+var dualAudioRegex = newReleaseTagRegex("dual(?:[\\s._-]*audio)?")
+var tenBitRegex = newReleaseTagRegex("10[\\s._-]?bits?|hi10p?")
+
+var resolutionRegex = regexp.MustCompile("(?i)(?:^|[^0-9])(\\d{3,4})p(?:[^\\p{L}\\p{N}]|$)")
+var frameSizeRegex = regexp.MustCompile("(?:^|[^0-9])\\d{3,4}[xX×](\\d{3,4})(?:[^0-9]|$)")
+var fourKRegex = newReleaseTagRegex("4k|uhd")
+
+var leadingGroupRegex = regexp.MustCompile("^\\s*\\[([^\\[\\]]+)\\]")
+var sceneGroupRegex = regexp.MustCompile("\\.([^.\\s]+)-([\\p{L}\\p{N}]+)$")
+var checksumLikeRegex = regexp.MustCompile("^[0-9a-fA-F]{8}$")
+var checksumTagRegex = regexp.MustCompile("[\\[(]([0-9a-fA-F]{8})[\\])]")
+
+func findReleaseTag(base string, tags []releaseTag) string {
+	for _, tag := range tags {
+		if tag.regex.MatchString(base) {
+			return tag.value
+		}
+	}
+	return ""
+}
+
+// isReleaseTag checks whether the whole string is a single tag, e.g. "1080p" or "WEB-DL"
+func isReleaseTag(s string) bool {
+	for _, tags := range [][]releaseTag{videoCodecTags, audioCodecTags, sourceTags} {
+		for _, tag := range tags {
+			if loc := tag.regex.FindStringIndex(s); loc != nil && loc[0] == 0 && loc[1] == len(s) {
+				return true
+			}
+		}
+	}
+	return parseResolution(s) != "" || isChecksum(s)
+}
+
+// isChecksum checks whether the string looks like a CRC32 checksum, dates like 20230514 are not checksums
+func isChecksum(s string) bool {
+	if !checksumLikeRegex.MatchString(s) {
+		return false
+	}
+	if test := compactDateRegex.FindStringSubmatch(s); test != nil {
+		if _, ok := newAirDate(test[1], test[2], test[3]); ok {
+			return false
+		}
+	}
+	return true
+}
+
+func parseResolution(base string) string {
+	if test := resolutionRegex.FindStringSubmatch(base); test != nil {
+		return test[1] + "p"
+	}
+	if test := frameSizeRegex.FindStringSubmatch(base); test != nil {
+		return test[1] + "p"
+	}
+	if fourKRegex.MatchString(base) {
+		return "2160p"
+	}
+	return ""
+}
+
+func parseReleaseGroup(base string) string {
+	// anime style, e.g. "[Judas] Show - 01"
+	if test := leadingGroupRegex.FindStringSubmatch(base); test != nil {
+		group := strings.TrimSpace(test[1])
+		if !isReleaseTag(group) {
+			return group
+		}
+	}
+	// scene style, e.g. "Show.S01E01.1080p.WEB-DL.x264-NTb"
+	if !strings.Contains(base, " ") {
+		if test := sceneGroupRegex.FindStringSubmatch(base); test != nil && !isReleaseTag(test[1]+"-"+test[2]) {
+			return test[2]
+		}
+	}
+	return ""
+}
+
+// parseReleaseInfo searches for release tags in the filename without path and extension
+func parseReleaseInfo(base string) ReleaseInfo {
+	return ReleaseInfo{
+		Group:      parseReleaseGroup(base),
+		Resolution: parseResolution(base),
+		VideoCodec: findReleaseTag(base, videoCodecTags),
+		AudioCodec: findReleaseTag(base, audioCodecTags),
+		Source:     findReleaseTag(base, sourceTags),
+		DualAudio:  dualAudioRegex.MatchString(base),
+		TenBit:     tenBitRegex.MatchString(base),
+		Checksum:   parseChecksum(base),
+	}
+}
+
+// parseChecksum returns the last CRC32 tag, it is usually placed at the end of the filename
+func parseChecksum(base string) string {
+	matches := checksumTagRegex.FindAllStringSubmatch(base, -1)
+	for i := len(matches) - 1; i >= 0; i-- {
+		if isChecksum(matches[i][1]) {
+			return strings.ToUpper(matches[i][1])
+		}
+	}
+	return ""
+}
@@ -0,0 +1,70 @@
+package roflmeta
+
+import (
+	"github.com/go-playground/assert/v2"
+	"testing"
+)
+
+func TestReleaseInfoAnime(t *testing.T) {
+	info := parseReleaseInfo("[DB]Kabukimonogatari_-_NCED01_(10bit_BD1080p_x265)")
+	assert.Equal(t, info.Group, "DB")
+	assert.Equal(t, info.Resolution, "1080p")
+	assert.Equal(t, info.VideoCodec, "H.265")
+	assert.Equal(t, info.Source, "BluRay")
+	assert.Equal(t, info.TenBit, true)
+	assert.Equal(t, info.DualAudio, false)
+}
+
+func TestReleaseInfoDualAudio(t *testing.T) {
+	info := parseReleaseInfo("Hellsing - Ep. 05 - Brotherhood (480p DVDRip - DUAL Audio)")
+	assert.Equal(t, info.Group, "")
+	assert.Equal(t, info.Resolution, "480p")
+	assert.Equal(t, info.Source, "DVD")
+	assert.Equal(t, info.DualAudio, true)
+}
+
+func TestReleaseInfoCodecs(t *testing.T) {
+	info := parseReleaseInfo("Koroshi Ai - 06 (WEBDL 1080p HEVC AAC) Ukr DVO")
+	assert.Equal(t, info.Source, "WEB-DL")
+	assert.Equal(t, info.VideoCodec, "H.265")
+	assert.Equal(t, info.AudioCodec, "AAC")
+
+	info = parseReleaseInfo("[yt-dlp] Orient - 15 (AMZN 1920x1080 H.264 E-AC-3) [99D81F63]")
+	assert.Equal(t, info.Group, "yt-dlp")
+	assert.Equal(t, info.Resolution, "1080p")
+	assert.Equal(t, info.VideoCodec, "H.264")
+	assert.Equal(t, info.AudioCodec, "E-AC3")
+
+	info = parseReleaseInfo("01. Sayonara Zetsubou Sensei [720p Hi10p AAC BDRip][kuchikirukia] [E24E9EB2]")
+	assert.Equal(t, info.Resolution, "720p")
+	assert.Equal(t, info.TenBit, true)
+	assert.Equal(t, info.Source, "BluRay")
+}
+
+func TestReleaseInfoScene(t *testing.T) {
+	info := parseReleaseInfo("The.Office.S02E03.1080p.WEB-DL.DDP5.1.x264-NTb")
+	assert.Equal(t, info.Group, "NTb")
+	assert.Equal(t, info.Source, "WEB-DL")
+	assert.Equal(t, info.AudioCodec, "E-AC3")
+	assert.Equal(t, info.VideoCodec, "H.264")
+
+	info = parseReleaseInfo("The.Office.S02E03.1080p.WEB-DL")
+	assert.Equal(t, info.Group, "")
+}
+
+func TestReleaseInfoEmpty(t *testing.T) {
+	info := parseReleaseInfo("[1080p] Show - 01")
+	assert.Equal(t, info.Group, "")
+	assert.Equal(t, parseReleaseInfo("01"), ReleaseInfo{})
+	assert.Equal(t, parseReleaseInfo("Hunter x Hunter (2011) - S01E012"), ReleaseInfo{})
+}
+
+func TestReleaseInfoChecksum(t *testing.T) {
+	assert.Equal(t, parseReleaseInfo("[SubsPlease] Heion Sedai no Idaten-tachi - 01 (1080p) [28b342e5]").Checksum, "28B342E5")
+	assert.Equal(t, parseReleaseInfo("[Commie] Sayonara Zetsubou Sensei (2012) - BD Special [BD 720p AAC] [BEA51F1F]").Checksum, "BEA51F1F")
+	assert.Equal(t, parseReleaseInfo("[DEADBEEF] Show - 01").Group, "")
+	assert.Equal(t, parseReleaseInfo("Show - 01 [1080p]").Checksum, "")
+	assert.Equal(t, parseReleaseInfo("Show - 2023-05-14 [20230514]").Checksum, "")
+	assert.Equal(t, parseReleaseInfo("Show - 01 [28B342E5] [20230514]").Checksum, "28B342E5")
+	assert.Equal(t, parseReleaseInfo("Show - 01 [12345678]").Checksum, "12345678")
+}
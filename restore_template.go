@@ -2,15 +2,20 @@ package roflmeta
 
 import (
 	"regexp"
+	"sort"
 	"strings"
 )
 
+const textVarRegex = "(.*?)"
+
 type template struct {
 	runes []rune
+	// varRegexes are regexes of the variables in order, variables match anything if it is nil
+	varRegexes []string
 }
 
 func newTemplate(s string) template {
-	return template{[]rune(s)}
+	return template{runes: []rune(s)}
 }
 
 func (t *template) varCount() int {
@@ -23,12 +28,34 @@ func (t *template) varCount() int {
 	return result
 }
 
+func (t *template) literalCount() int {
+	return len(t.runes) - t.varCount()
+}
+
+// isSpecific checks that constant part of the template covers at least a half of the longest filename
+func (t *template) isSpecific(filenames []string) bool {
+	return t.specificity(filenames) >= 1
+}
+
+// specificity is the share of the longest filename covered by constant part of the template, doubled,
+// so that specific templates have at least 1
+func (t *template) specificity(filenames []string) float64 {
+	maxLen := 0
+	for _, s := range filenames {
+		maxLen = max(maxLen, len([]rune(s)))
+	}
+	if maxLen == 0 {
+		return 0
+	}
+	return float64(t.literalCount()*2) / float64(maxLen)
+}
+
 func (t *template) check(filenames []string) error {
 	regex := t.toRegex()
 	for _, s := range filenames {
 		test := regex.FindStringSubmatch(s)
 		if test == nil {
-			return errInvalidTemplate
+			return ErrInvalidTemplate
 		}
 	}
 	return nil
@@ -57,11 +84,25 @@ func (t *template) fix(filenames []string) template {
 		}
 	}
 	if len(varsToRemove) > 0 {
-		return t.removeVars(varsToRemove)
+		fixed := t.removeVars(varsToRemove)
+		// whitespace-only values are considered empty, they may still be needed to match, e.g. " Show" and "Show"
+		if fixed.check(filenames) == nil {
+			return fixed
+		}
 	}
 	return *t
 }
 
+// basePrefix returns the constant beginning of the file name without path, delimiters are replaced with spaces
+func (t *template) basePrefix() string {
+	s := t.String()
+	s = s[strings.LastIndex(s, "/")+1:]
+	if index := strings.IndexRune(s, '*'); index >= 0 {
+		s = s[:index]
+	}
+	return strings.ReplaceAll(delimiterRegex.ReplaceAllLiteralString(s, " "), ".", " ")
+}
+
 func (t *template) removeVars(indices []int) template {
 	if len(indices) == 0 {
 		return *t
@@ -79,18 +120,24 @@ func (t *template) removeVars(indices []int) template {
 		}
 		runes = append(runes, r)
 	}
-	return template{runes}
+	return template{runes: runes}
 }
 
 func (t *template) toRegexString() string {
 	var builder strings.Builder
 	var subBuilder strings.Builder
 	builder.WriteRune('^')
+	varIndex := 0
 	for _, r := range t.runes {
 		if r == '*' {
 			builder.WriteString(regexp.QuoteMeta(subBuilder.String()))
 			subBuilder.Reset()
-			builder.WriteString("(.*?)")
+			if t.varRegexes != nil {
+				builder.WriteString(t.varRegexes[varIndex])
+			} else {
+				builder.WriteString(textVarRegex)
+			}
+			varIndex++
 		} else {
 			subBuilder.WriteRune(r)
 		}
@@ -122,7 +169,7 @@ func (t *template) merge(other template) template {
 		}
 		runes = append(runes, r)
 	}
-	return template{runes}
+	return template{runes: runes}
 }
 
 func (t *template) String() string {
@@ -207,7 +254,7 @@ func findTemplateForPair(str1 []rune, str2 []rune) template {
 			result[resultI] = '*'
 		}
 	}
-	return template{result}
+	return template{runes: result}
 }
 
 func restoreTemplate(filenames []string) (*template, error) {
@@ -215,7 +262,7 @@ func restoreTemplate(filenames []string) (*template, error) {
 		return &template{}, nil
 	}
 	if len(filenames) == 1 {
-		return &template{[]rune(filenames[0])}, nil
+		return &template{runes: []rune(filenames[0])}, nil
 	}
 	curTemplate := findTemplateForPair([]rune(filenames[0]), []rune(filenames[1]))
 	for i := 2; i < len(filenames); i++ {
@@ -229,3 +276,166 @@ func restoreTemplate(filenames []string) (*template, error) {
 	curTemplate = curTemplate.fix(filenames)
 	return &curTemplate, nil
 }
+
+// outlierDivisor limits outliers to a minority, at most a quarter of the files may not follow the template,
+// but a single outlier is allowed among 3 files, e.g. two episodes and "Sample.mkv"
+const outlierDivisor = 4
+
+// minOutlierFiles is the smallest number of files that may have an outlier
+const minOutlierFiles = 3
+
+// maxOutlierAttempts limits the number of restorations with files left out
+const maxOutlierAttempts = 8
+
+// maxOutlierCount returns the largest number of outliers among count files
+func maxOutlierCount(count int) int {
+	result := max(1, count/outlierDivisor)
+	if result > maxOutlierAttempts {
+		result = maxOutlierAttempts
+	}
+	return result
+}
+
+// outlierPartners is the number of files each file is compared with to tell outliers apart
+const outlierPartners = 4
+
+// outlierPartnerIndices returns indices of files spread evenly across the list, excluding the file itself
+func outlierPartnerIndices(index int, count int) []int {
+	step := max(1, (count-1)/outlierPartners)
+	result := make([]int, 0, outlierPartners)
+	for j := index + step; len(result) < outlierPartners && j < index+count; j += step {
+		result = append(result, j%count)
+	}
+	return result
+}
+
+// similarityScores rates how similar each filename is to the others, it is the sum of constant shares
+// of pair templates with a few other files
+func similarityScores(filenames []string) []float64 {
+	result := make([]float64, len(filenames))
+	for i, name := range filenames {
+		for _, j := range outlierPartnerIndices(i, len(filenames)) {
+			pair := findTemplateForPair([]rune(name), []rune(filenames[j]))
+			maxLen := max(len([]rune(name)), len([]rune(filenames[j])))
+			if maxLen > 0 {
+				result[i] += float64(pair.literalCount()) / float64(maxLen)
+			}
+		}
+	}
+	return result
+}
+
+// restoreTemplateWithOutliers finds the template of the largest consistent subset of filenames,
+// e.g. a stray "Sample.mkv" or "Show Ep 7 FINAL.mkv" doesn't break the template of other episodes
+// The least similar files are left out one by one until the rest has a specific template accepted by the callback,
+// accept receives indices of the filenames that don't follow the template in ascending order, there is at least one
+// The search stops as soon as leaving out one more file doesn't make the template more specific than the previous one,
+// full is the template of all filenames, nil if there's none
+// Returned error is ErrInvalidTemplate if there's no such template or outliers are not a minority
+func restoreTemplateWithOutliers(filenames []string, full *template, accept func(t *template, outliers []int) bool) (*template, []int, error) {
+	if len(filenames) < minOutlierFiles {
+		return nil, nil, ErrInvalidTemplate
+	}
+	maxOutliers := maxOutlierCount(len(filenames))
+	literalCount := 0
+	if full != nil {
+		literalCount = full.literalCount()
+	}
+	scores := similarityScores(filenames)
+	order := make([]int, len(filenames))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return scores[order[i]] < scores[order[j]]
+	})
+
+	for count := 1; count <= maxOutliers; count++ {
+		left := make(map[int]struct{}, count)
+		for _, i := range order[:count] {
+			left[i] = struct{}{}
+		}
+		subset := make([]string, 0, len(filenames)-count)
+		for i, name := range filenames {
+			if _, ok := left[i]; !ok {
+				subset = append(subset, name)
+			}
+		}
+		t, err := restoreTemplate(subset)
+		if err != nil || t.literalCount() <= literalCount {
+			break
+		}
+		literalCount = t.literalCount()
+		if !t.isSpecific(subset) {
+			continue
+		}
+		// files left out may still follow the template
+		regex := t.toRegex()
+		outliers := make([]int, 0, count)
+		for i, name := range filenames {
+			if !regex.MatchString(name) {
+				outliers = append(outliers, i)
+			}
+		}
+		if len(outliers) > 0 && accept(t, outliers) {
+			return t, outliers, nil
+		}
+	}
+	return nil, nil, ErrInvalidTemplate
+}
+
+// Template is a filename template restored by RestoreTemplate
+type Template struct {
+	template  template
+	regex     *regexp.Regexp
+	filenames []string
+}
+
+// RestoreTemplate finds the template all filenames follow, variable parts of the filenames are marked with '*',
+// e.g. "Show - *.mkv" for "Show - 01.mkv" and "Show - 02.mkv"
+// Returned error is ErrInvalidTemplate if restored template doesn't match all filenames
+func RestoreTemplate(filenames []string) (*Template, error) {
+	t, err := restoreTemplate(filenames)
+	if err != nil {
+		return nil, err
+	}
+	return &Template{
+		template:  *t,
+		regex:     t.toRegex(),
+		filenames: append([]string{}, filenames...),
+	}, nil
+}
+
+// String returns the template with variables marked with '*'
+func (t *Template) String() string {
+	return t.template.String()
+}
+
+// VarCount returns the number of variables
+func (t *Template) VarCount() int {
+	return t.template.varCount()
+}
+
+// Regex returns the regex matching the whole filename, each variable is a capturing group
+func (t *Template) Regex() *regexp.Regexp {
+	return t.regex
+}
+
+// Match returns values of the variables for the filename, false if it doesn't match the template
+func (t *Template) Match(filename string) ([]string, bool) {
+	test := t.regex.FindStringSubmatch(filename)
+	if test == nil {
+		return nil, false
+	}
+	return test[1:], true
+}
+
+// Values returns values of the variables for each filename the template was restored from, in the same order
+func (t *Template) Values() [][]string {
+	result := make([][]string, 0, len(t.filenames))
+	for _, filename := range t.filenames {
+		values, _ := t.Match(filename)
+		result = append(result, values)
+	}
+	return result
+}
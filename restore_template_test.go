@@ -156,3 +156,41 @@ func TestThreeVars(t *testing.T) {
 	}
 	testRestore(t, "nartsiss 0*/nartsiss *x0*.mkv", strings...)
 }
+
+func TestRestoreTemplatePublic(t *testing.T) {
+	result, err := RestoreTemplate([]string{"Show S1E2.mkv", "Show S3E4.mkv", "Show S5E6.mkv"})
+	if err != nil {
+		t.Fatalf("Template restoration failed: %v", err)
+	}
+	if result.String() != "Show S*E*.mkv" || result.VarCount() != 2 {
+		t.Fatalf("Invalid template %s with %d vars", result.String(), result.VarCount())
+	}
+	expected := [][]string{{"1", "2"}, {"3", "4"}, {"5", "6"}}
+	if !reflect.DeepEqual(result.Values(), expected) {
+		t.Fatalf("Invalid values: expected %v, got %v", expected, result.Values())
+	}
+	if values, ok := result.Match("Show S7E08.mkv"); !ok || !reflect.DeepEqual(values, []string{"7", "08"}) {
+		t.Fatalf("Invalid match: %v", values)
+	}
+	if _, ok := result.Match("Other - 14.mkv"); ok || !result.Regex().MatchString("Show SE.mkv") {
+		t.Fatalf("Invalid regex %s", result.Regex().String())
+	}
+}
+
+func TestRestoreWithOutliers(t *testing.T) {
+	filenames := []string{"Show - 01.mkv", "Show - 02.mkv", "Show - 13.mkv", "Show Ep 4 FINAL.mkv", "Show - 05.mkv", "Show - 16.mkv"}
+	acceptAll := func(t *template, outliers []int) bool {
+		return true
+	}
+	result, outliers, err := restoreTemplateWithOutliers(filenames, nil, acceptAll)
+	if err != nil {
+		t.Fatalf("Template restoration failed: %v", err)
+	}
+	if result.String() != "Show - *.mkv" || !reflect.DeepEqual(outliers, []int{3}) {
+		t.Fatalf("Invalid result %s with outliers %v", result.String(), outliers)
+	}
+	// outliers must be a minority
+	if _, _, err := restoreTemplateWithOutliers(append(filenames, "Show Ep 9 FINAL.mkv"), nil, acceptAll); err != ErrInvalidTemplate {
+		t.Fatalf("Expected ErrInvalidTemplate, got %v", err)
+	}
+}
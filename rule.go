@@ -0,0 +1,89 @@
+package roflmeta
+
+import "strings"
+
+// Rule is a heuristic of the single file parser, see WithRule, WithRuleBefore and WithRuleAfter
+// Built-in heuristics are rules too, their names are listed in Rule* constants
+type Rule interface {
+	// Name is reported in Explanation and is used to place other rules before or after this one
+	Name() string
+	// Apply inspects the state and may fill or refine its result
+	// Returning true claims the filename, the result is final and the remaining rules are skipped
+	// Claims without Episode are ignored
+	Apply(state *RuleState) bool
+}
+
+// RuleState is the working state of the single file parser shared by its rules
+type RuleState struct {
+	// Base is the filename without path, extension and version suffix, e.g. "[Judas] Show - 05"
+	Base string
+	// Working is the filename with delimiters replaced by spaces, rules remove the parts they have used
+	// Starting with RuleStartsWithNumber, built-in rules work with clusters of words separated by multiple spaces,
+	// they are split again if a rule changes the working string
+	Working string
+	// Result is the partial result, only Title, Season, Episode, EpisodeEnd and Confidence are used
+	Result EpisodeMetadata
+
+	parser   *Parser
+	clusters []string
+	// clustersOf is the working string the clusters were split from
+	clustersOf string
+}
+
+// getClusters splits the working string into clusters of words separated by multiple spaces,
+// it is split again only if the working string was changed since then
+func (s *RuleState) getClusters() []string {
+	if s.clusters == nil || s.Working != s.clustersOf {
+		// years are not episodes, e.g. "Show 2011 - 05"
+		s.Working = strings.Trim(removeYears(s.Working), " ")
+		s.clusters = clusterRegex.Split(s.Working, -1)
+		s.clustersOf = s.Working
+	}
+	return s.clusters
+}
+
+// setCluster replaces the cluster and keeps the working string in sync, so that other rules see the change
+func (s *RuleState) setCluster(index int, value string) {
+	s.clusters[index] = value
+	s.Working = strings.Join(s.clusters, "  ")
+	s.clustersOf = s.Working
+}
+
+// builtinRule reports tried matches to the explanation on its own
+type builtinRule struct {
+	name  string
+	apply func(state *RuleState, explanation *Explanation) bool
+}
+
+func (r builtinRule) Name() string {
+	return r.name
+}
+
+func (r builtinRule) Apply(state *RuleState) bool {
+	return r.apply(state, nil)
+}
+
+func findRule(rules []Rule, name string) int {
+	for i, rule := range rules {
+		if rule.Name() == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func insertRule(rules []Rule, index int, rule Rule) []Rule {
+	rules = append(rules, nil)
+	copy(rules[index+1:], rules[index:])
+	rules[index] = rule
+	return rules
+}
+
+// RuleNames returns names of the single file parser rules in the order they are tried
+func (p *Parser) RuleNames() []string {
+	names := make([]string, 0, len(p.rules))
+	for _, rule := range p.rules {
+		names = append(names, rule.Name())
+	}
+	return names
+}
@@ -0,0 +1,150 @@
+package roflmeta
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var romanValues = map[rune]int{'I': 1, 'V': 5, 'X': 10}
+
+var ordinalWords = map[string]int{
+	"first": 1, "second": 2, "third": 3, "fourth": 4, "fifth": 5,
+	"sixth": 6, "seventh": 7, "eighth": 8, "ninth": 9, "tenth": 10,
+}
+
+var ordinalSeasonRegex = regexp.MustCompile("(?i)(?:^|\\s)(\\d+)(?:st|nd|rd|th)\\s+season(?:\\s|$)")
+var wordSeasonRegex = regexp.MustCompile("(?i)(?:^|\\s)(first|second|third|fourth|fifth|sixth|seventh|eighth|ninth|tenth)\\s+season(?:\\s|$)")
+var romanSeasonRegex = regexp.MustCompile("(?i)(?:^|\\s)season\\s+([ivx]+)(?:\\s|$)")
+var finalSeasonRegex = regexp.MustCompile("(?i)(?:^|\\s)(?:the\\s+)?final\\s+season(?:\\s|$)")
+
+// roman numeral at the end of the title, e.g. "Overlord III" or "Mob Psycho 100 II"
+// single letters are ignored, they are too common in titles, e.g. "Hunter X Hunter"
+var romanTitleRegex = regexp.MustCompile("[\\p{L}\\p{N}!?.](\\s+([IVX]{2,5}))(?:\\s{2,}|\\s*$|\\s+\\d)")
+
+var partRegex = regexp.MustCompile("(?i)(?:^|\\s)(?:part|cour)\\s*(\\d+|[ivx]+)(?:\\s|$)")
+var ordinalPartRegex = regexp.MustCompile("(?i)(?:^|\\s)(\\d+)(?:st|nd|rd|th)\\s+(?:part|cour)(?:\\s|$)")
+var wordPartRegex = regexp.MustCompile("(?i)(?:^|\\s)(first|second|third|fourth|fifth|sixth|seventh|eighth|ninth|tenth)\\s+(?:part|cour)(?:\\s|$)")
+var partPrefixRegex = regexp.MustCompile("(?i)(?:part|cour)[\\s._-]*$")
+
+const finalSeason = "Final"
+
+// parseRoman parses roman numerals up to 39, e.g. "XIV"
+func parseRoman(s string) (int, bool) {
+	s = strings.ToUpper(s)
+	if s == "" {
+		return 0, false
+	}
+	total := 0
+	previous := 0
+	runes := []rune(s)
+	for i := len(runes) - 1; i >= 0; i-- {
+		value, ok := romanValues[runes[i]]
+		if !ok {
+			return 0, false
+		}
+		if value < previous {
+			total -= value
+		} else {
+			total += value
+			previous = value
+		}
+	}
+	// reject malformed numerals like "IIII" or "VX"
+	if toRoman(total) != s {
+		return 0, false
+	}
+	return total, true
+}
+
+func toRoman(value int) string {
+	numerals := []struct {
+		value  int
+		symbol string
+	}{{10, "X"}, {9, "IX"}, {5, "V"}, {4, "IV"}, {1, "I"}}
+	var sb strings.Builder
+	for _, numeral := range numerals {
+		for value >= numeral.value {
+			sb.WriteString(numeral.symbol)
+			value -= numeral.value
+		}
+	}
+	return sb.String()
+}
+
+// parseSeasonWord searches for seasons written as words, e.g. "2nd Season", "Second Season", "Season II", "Final Season"
+// or as roman numerals at the end of the title, e.g. "Overlord III"
+// Returns season and the matched text
+func parseSeasonWord(s string) (string, string) {
+	if test := ordinalSeasonRegex.FindStringSubmatch(s); test != nil {
+		return canonicalNumber(test[1]), test[0]
+	}
+	if test := wordSeasonRegex.FindStringSubmatch(s); test != nil {
+		return strconv.Itoa(ordinalWords[strings.ToLower(test[1])]), test[0]
+	}
+	if test := romanSeasonRegex.FindStringSubmatch(s); test != nil {
+		if value, ok := parseRoman(test[1]); ok {
+			return strconv.Itoa(value), test[0]
+		}
+	}
+	if test := finalSeasonRegex.FindStringSubmatch(s); test != nil {
+		return finalSeason, test[0]
+	}
+	if test := romanTitleRegex.FindStringSubmatch(s); test != nil {
+		if value, ok := parseRoman(test[2]); ok {
+			return strconv.Itoa(value), test[1]
+		}
+	}
+	return "", ""
+}
+
+// parsePart searches for split season markers, e.g. "Part 2", "Cour 2", "Part II", "2nd Cour" or "Second Part"
+// Returns part number (0 if not found) and the matched text
+func parsePart(s string) (int, string) {
+	if test := ordinalPartRegex.FindStringSubmatch(s); test != nil {
+		value, _ := strconv.Atoi(test[1])
+		return value, test[0]
+	}
+	if test := wordPartRegex.FindStringSubmatch(s); test != nil {
+		return ordinalWords[strings.ToLower(test[1])], test[0]
+	}
+	if test := partRegex.FindStringSubmatch(s); test != nil {
+		if value, ok := parsePartNumber(test[1]); ok {
+			return value, test[0]
+		}
+	}
+	return 0, ""
+}
+
+func parsePartNumber(s string) (int, bool) {
+	if value, err := strconv.Atoi(s); err == nil {
+		return value, true
+	}
+	return parseRoman(s)
+}
+
+// isPartGroup checks whether the template variable is a part number, e.g. "Show Part *" or a value like "Part 2"
+func isPartGroup(filename string, regex *regexp.Regexp, group int) bool {
+	test := regex.FindStringSubmatchIndex(filename)
+	if test == nil || test[2*group] < 0 {
+		return false
+	}
+	value := postCleanData(filename[test[2*group]:test[2*group+1]])
+	if _, match := parsePart(value); match != "" && strings.TrimSpace(match) == value {
+		return true
+	}
+	if _, ok := parsePartNumber(value); !ok {
+		return false
+	}
+	return partPrefixRegex.MatchString(filename[:test[2*group]])
+}
+
+// templatePart returns part number of a template variable, see isPartGroup
+func templatePart(value string) int {
+	value = postCleanData(value)
+	if part, ok := parsePartNumber(value); ok {
+		return part
+	}
+	part, _ := parsePart(value)
+	return part
+}
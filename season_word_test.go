@@ -0,0 +1,55 @@
+package roflmeta
+
+import (
+	"github.com/go-playground/assert/v2"
+	"testing"
+)
+
+func TestParseRoman(t *testing.T) {
+	value, ok := parseRoman("III")
+	assert.Equal(t, ok, true)
+	assert.Equal(t, value, 3)
+	value, ok = parseRoman("xiv")
+	assert.Equal(t, ok, true)
+	assert.Equal(t, value, 14)
+	_, ok = parseRoman("IIII")
+	assert.Equal(t, ok, false)
+	_, ok = parseRoman("VX")
+	assert.Equal(t, ok, false)
+}
+
+func TestParseSeasonWord(t *testing.T) {
+	season, _ := parseSeasonWord("Kaguya sama 2nd Season   05")
+	assert.Equal(t, season, "2")
+	season, _ = parseSeasonWord("Show Second Season")
+	assert.Equal(t, season, "2")
+	season, _ = parseSeasonWord("Show Season IV")
+	assert.Equal(t, season, "4")
+	season, _ = parseSeasonWord("Shingeki no Kyojin The Final Season   05")
+	assert.Equal(t, season, finalSeason)
+	season, match := parseSeasonWord("Overlord III   05")
+	assert.Equal(t, season, "3")
+	assert.Equal(t, match, " III")
+	season, _ = parseSeasonWord("Hunter X Hunter   Movie 1")
+	assert.Equal(t, season, "")
+	season, _ = parseSeasonWord("Show II Electric Boogaloo")
+	assert.Equal(t, season, "")
+}
+
+func TestParsePart(t *testing.T) {
+	part, _ := parsePart("Attack on Titan Final Season Part 2   05")
+	assert.Equal(t, part, 2)
+	part, _ = parsePart("Show Cour 2")
+	assert.Equal(t, part, 2)
+	part, _ = parsePart("Show Part II")
+	assert.Equal(t, part, 2)
+	part, _ = parsePart("Show Party 2")
+	assert.Equal(t, part, 0)
+}
+
+func TestParsePartOrdinal(t *testing.T) {
+	part, _ := parsePart("Show 2nd Cour   05")
+	assert.Equal(t, part, 2)
+	part, _ = parsePart("Show Second Part")
+	assert.Equal(t, part, 2)
+}
@@ -5,11 +5,27 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
-var errInvalidTemplate = errors.New("invalid template")
-var errRegexFailed = errors.New("regex failed")
-var errMultipleFailed = errors.New("multiple method failed")
+// ErrInvalidTemplate is reported when restored template doesn't match all filenames
+var ErrInvalidTemplate = errors.New("invalid template")
+
+// ErrRegexFailed is reported when template regex doesn't match one of the filenames
+var ErrRegexFailed = errors.New("regex failed")
+
+// ErrMultipleFailed is reported when template variables can't be mapped to seasons and episodes
+var ErrMultipleFailed = errors.New("multiple method failed")
+
+// ErrSingleParserOnly is reported when no directory was parsed using the template method
+var ErrSingleParserOnly = errors.New("all directories were parsed by the single file parser")
+
+// ErrInvalidNamingTemplate is reported when naming template has unknown placeholders, literal '*' or lacks {episode}
+var ErrInvalidNamingTemplate = errors.New("invalid naming template")
+
+// ErrNoChecksum is reported when CRC32 checksum tag is missing or malformed
+var ErrNoChecksum = errors.New("no checksum")
 
 func substringStart(input string, start int) string {
 	runes := []rune(input)
@@ -61,3 +77,39 @@ func longestCommonPrefix(arr []string) int {
 	}
 	return i
 }
+
+// cleanTitle removes brackets and delimiters and returns the first cluster of words
+func cleanTitle(s string) string {
+	s = bracketRemoveRegex.ReplaceAllLiteralString(s, "")
+	s = delimiterRegex.ReplaceAllLiteralString(s, " ")
+	// dots are used instead of spaces, e.g. The.Office.S01E01
+	if !strings.Contains(strings.TrimSpace(s), " ") {
+		s = strings.ReplaceAll(s, ".", " ")
+	}
+	for _, cluster := range clusterRegex.Split(strings.TrimSpace(s), -1) {
+		if cluster = postCleanData(cluster); cluster != "" {
+			return removeTrailingYear(cluster)
+		}
+	}
+	return ""
+}
+
+// titleBefore returns title located before the match, e.g. before S01E01
+func titleBefore(s string, match string) string {
+	index := strings.Index(s, match)
+	if index <= 0 {
+		return ""
+	}
+	return cleanTitle(s[:index])
+}
+
+// splitsWord checks whether value starts in the middle of a word of s, e.g. "ample" in "Sample"
+func splitsWord(s string, value string) bool {
+	index := strings.LastIndex(s, value)
+	if index <= 0 || value == "" {
+		return false
+	}
+	before, _ := utf8.DecodeLastRuneInString(s[:index])
+	first, _ := utf8.DecodeRuneInString(value)
+	return unicode.IsLetter(before) && unicode.IsLetter(first)
+}
@@ -0,0 +1,23 @@
+package roflmeta
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// glued to the episode number, e.g. "05v2", or standalone tag, e.g. "[v3]"
+var versionSuffixRegex = regexp.MustCompile("(?i)(\\d)v(\\d{1,2})([^\\p{L}\\p{N}]|$)")
+var versionTagRegex = regexp.MustCompile("(?i)[\\[(]v(\\d{1,2})[\\])]")
+
+// parseVersion returns release version (0 if not specified) and the string without version suffixes and tags
+func parseVersion(s string) (int, string) {
+	version := 0
+	if test := versionSuffixRegex.FindStringSubmatch(s); test != nil {
+		version, _ = strconv.Atoi(test[2])
+	} else if test := versionTagRegex.FindStringSubmatch(s); test != nil {
+		version, _ = strconv.Atoi(test[1])
+	}
+	s = versionSuffixRegex.ReplaceAllString(s, "${1}${3}")
+	s = versionTagRegex.ReplaceAllLiteralString(s, "")
+	return version, s
+}
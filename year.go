@@ -0,0 +1,85 @@
+package roflmeta
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var yearRegex = regexp.MustCompile("(^|[^\\p{L}\\p{N}])((?:19|20)\\d{2})([^\\p{L}\\p{N}]|$)")
+var trailingYearRegex = regexp.MustCompile("\\s+((?:19|20)\\d{2})$")
+var emptyBracketsRegex = regexp.MustCompile("\\(\\s*\\)|\\[\\s*\\]|\\{\\s*\\}")
+
+// maxPlausibleYear is the latest release year, it is fixed, so that results don't depend on the current date
+const maxPlausibleYear = 2035
+
+// isPlausibleYear rejects far future years, so that titles like "Blade Runner 2049" stay intact
+func isPlausibleYear(value string) bool {
+	if len(value) != 4 || !fullNumberRegex.MatchString(value) {
+		return false
+	}
+	year, _ := strconv.Atoi(value)
+	return year >= 1900 && year <= maxPlausibleYear
+}
+
+// parseYear returns the first plausible year, e.g. 2011 for "Hunter x Hunter (2011)", 0 if not found
+func parseYear(s string) int {
+	for _, test := range yearRegex.FindAllStringSubmatch(s, -1) {
+		if isPlausibleYear(test[2]) {
+			year, _ := strconv.Atoi(test[2])
+			return year
+		}
+	}
+	return 0
+}
+
+// removeYears removes plausible years if there are other numbers, that are better episode candidates,
+// e.g. "Show 2011 05" becomes "Show  05", but "Show 2011" stays as is
+// Brackets left empty are removed too, e.g. "Show (2011) 05" becomes "Show  05"
+func removeYears(s string) string {
+	years := 0
+	others := 0
+	tokens := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, token := range tokens {
+		if isPlausibleYear(token) {
+			years++
+		} else if fullNumberRegex.MatchString(token) {
+			others++
+		}
+	}
+	if years == 0 || others == 0 {
+		return s
+	}
+	s = yearRegex.ReplaceAllStringFunc(s, func(match string) string {
+		test := yearRegex.FindStringSubmatch(match)
+		if !isPlausibleYear(test[2]) {
+			return match
+		}
+		return test[1] + test[3]
+	})
+	return emptyBracketsRegex.ReplaceAllLiteralString(s, " ")
+}
+
+// removeTrailingYear removes the release year from the title, e.g. "Show 2019" becomes "Show"
+func removeTrailingYear(title string) string {
+	test := trailingYearRegex.FindStringSubmatch(title)
+	if test == nil || !isPlausibleYear(test[1]) {
+		return title
+	}
+	return strings.TrimSpace(strings.TrimSuffix(title, test[0]))
+}
+
+// releaseYear returns the year of the air date or the first plausible year, unless the year was taken as the episode
+func releaseYear(base string, result EpisodeMetadata) int {
+	if !result.AirDate.IsZero() {
+		return result.AirDate.Year()
+	}
+	year := parseYear(base)
+	if year == 0 || result.Episode == strconv.Itoa(year) {
+		return 0
+	}
+	return year
+}
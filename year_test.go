@@ -0,0 +1,25 @@
+package roflmeta
+
+import (
+	"github.com/go-playground/assert/v2"
+	"testing"
+)
+
+func TestParseYear(t *testing.T) {
+	assert.Equal(t, parseYear("[Judas] Hunter x Hunter (2011) - S01E012"), 2011)
+	assert.Equal(t, parseYear("Show.2019.S01E03"), 2019)
+	assert.Equal(t, parseYear("Blade Runner 2049"), 0)
+	assert.Equal(t, parseYear("Show 2035 - 05"), 2035)
+	assert.Equal(t, parseYear("Show 2036 - 05"), 0)
+	assert.Equal(t, parseYear("Show 1920x1080 - 05"), 0)
+	assert.Equal(t, parseYear("Show - 05 (2160p)"), 0)
+}
+
+func TestRemoveYears(t *testing.T) {
+	assert.Equal(t, removeYears("Show 2011   05"), "Show    05")
+	assert.Equal(t, removeYears("Show 2011"), "Show 2011")
+	assert.Equal(t, removeYears("Show 2011 1080p"), "Show 2011 1080p")
+	assert.Equal(t, removeYears("Show (2011)   05"), "Show     05")
+	assert.Equal(t, removeTrailingYear("Show 2019"), "Show")
+	assert.Equal(t, removeTrailingYear("Blade Runner 2049"), "Blade Runner 2049")
+}